@@ -0,0 +1,17 @@
+package impala
+
+import (
+	"time"
+
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// Date is a query argument for a DATE value, without time of day and time zone.
+// time.Time arguments are sent as TIMESTAMP values.
+type Date = isql.Date
+
+// DateOf returns the date of t in the location of t
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
@@ -0,0 +1,42 @@
+package impala
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// QueryArrow runs query with args on conn and writes the result to w as an Apache Arrow IPC stream, with a record
+// batch for each fetched batch of rows. args are bound to placeholders like in sql.Conn.QueryContext.
+// Column types map to Arrow types as follows:
+//
+//   - BOOLEAN to bool
+//   - TINYINT, SMALLINT, INT and BIGINT to int8, int16, int32 and int64
+//   - FLOAT and DOUBLE to float32 and float64
+//   - DECIMAL to decimal128 with the precision and scale of the column
+//   - TIMESTAMP to timestamp[ns] without time zone
+//   - DATE to date32
+//   - BINARY to binary
+//   - NULL to null
+//   - other types, including STRING, VARCHAR, CHAR and complex types, to utf8
+//
+// Only the standard library is used to write the stream, so Arrow libraries are not needed unless the stream
+// is read in the same process. If writing fails, the query is closed and the error is returned.
+// The stream is incomplete in that case.
+//
+// *sql.Conn implements ConnRawAccess.
+func QueryArrow(ctx context.Context, conn ConnRawAccess, query string, w io.Writer, args ...any) error {
+	return conn.Raw(func(driverConn any) error {
+		impalaConn, ok := driverConn.(*isql.Conn)
+		if !ok {
+			return errors.New("arrow queries can operate only on Impala drivers")
+		}
+		rows, err := queryRows(ctx, impalaConn, query, args)
+		if err != nil {
+			return err
+		}
+		return errors.Join(rows.WriteArrow(w), rows.Close())
+	})
+}
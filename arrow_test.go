@@ -0,0 +1,54 @@
+package impala
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestQueryArrow(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeHS2{batches: 2}
+	db := startPrefetch(t, fake, 4, 0)
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var out bytes.Buffer
+	require.NoError(t, QueryArrow(ctx, conn, "SELECT x FROM t", &out))
+	stream := out.Bytes()
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff}, stream[:4])
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}, stream[len(stream)-8:])
+	require.Contains(t, string(stream), "x\x00", "the schema has the column name")
+	for batch := range 2 {
+		var values []byte
+		for i := range 4 {
+			values = binary.LittleEndian.AppendUint32(values, uint32(4*batch+i))
+		}
+		require.True(t, bytes.Contains(stream, values), "batch %d", batch)
+	}
+	require.Equal(t, "CloseOperation", lo.LastOrEmpty(fake.getCalls()))
+
+	out.Reset()
+	require.NoError(t, QueryArrow(ctx, conn, "SELECT x FROM t WHERE d = ?", &out, Date{Year: 2024, Month: 3, Day: 1}))
+	require.Equal(t, "SELECT x FROM t WHERE d = CAST('2024-03-01' AS DATE)", lo.LastOrEmpty(fake.getStatements()))
+
+	err = QueryArrow(ctx, conn, "SELECT ?", &out, struct{}{})
+	require.ErrorContains(t, err, "converting argument 1")
+
+	err = QueryArrow(ctx, conn, "SELECT x FROM t", failingWriter{})
+	require.ErrorContains(t, err, "disk full")
+	require.Equal(t, "CloseOperation", lo.LastOrEmpty(fake.getCalls()))
+	require.NoError(t, conn.PingContext(ctx))
+}
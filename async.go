@@ -0,0 +1,245 @@
+package impala
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/hive"
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// AsyncQuery runs queries that are not bound to a connection or even to a process, such as long-running DML.
+// Submit starts a query and returns a QueryHandle. The other methods accept the handle with any Impala
+// connection (*sql.Conn implements ConnRawAccess) to the same coordinator, authenticated as the same user.
+// Close must be called for each submitted query to release its server resources.
+// The zero value is ready to use.
+//
+// Each query runs in its own session, which is not closed together with the connection.
+// Nevertheless, Impala closes sessions some time after their last connection closes - see the
+// disconnected_session_timeout flag - so a process must attach to a query within that time.
+type AsyncQuery struct{}
+
+// QueryHandle identifies a query started with AsyncQuery.Submit.
+// It can be serialized e.g. with encoding/json and used by another process to attach to the query.
+// The handle contains secrets that give access to the query so it must be stored securely.
+type QueryHandle struct {
+	SessionGUID     []byte `json:"session_guid"`
+	SessionSecret   []byte `json:"session_secret"`
+	OperationGUID   []byte `json:"operation_guid"`
+	OperationSecret []byte `json:"operation_secret"`
+	HasResultSet    bool   `json:"has_result_set"`
+}
+
+// QueryID returns the Impala query ID of the query in the hi:lo format that Impala shows
+func (h QueryHandle) QueryID() string {
+	return hive.QueryID(h.OperationGUID)
+}
+
+// QueryState is the execution state of a query started with AsyncQuery.Submit
+type QueryState int
+
+// QueryState values
+const (
+	QueryStateUnknown QueryState = iota
+	QueryStatePending
+	QueryStateRunning
+	QueryStateFinished
+	QueryStateCanceled
+	QueryStateFailed
+	QueryStateClosed
+)
+
+func (s QueryState) String() string {
+	switch s {
+	case QueryStatePending:
+		return "PENDING"
+	case QueryStateRunning:
+		return "RUNNING"
+	case QueryStateFinished:
+		return "FINISHED"
+	case QueryStateCanceled:
+		return "CANCELED"
+	case QueryStateFailed:
+		return "FAILED"
+	case QueryStateClosed:
+		return "CLOSED"
+	}
+	return "UNKNOWN"
+}
+
+// QueryStatus is the status of a query started with AsyncQuery.Submit
+type QueryStatus struct {
+	State QueryState
+	// ErrorMessage is the error reported by the server if State is QueryStateFailed
+	ErrorMessage string
+}
+
+// Done returns true if the query will not make further progress
+func (s QueryStatus) Done() bool {
+	return s.State != QueryStatePending && s.State != QueryStateRunning
+}
+
+// Submit starts a query and returns without waiting for it to finish.
+// The query runs as the user selected by Options.ImpersonateUser and WithImpersonateUser, if any.
+func (AsyncQuery) Submit(ctx context.Context, conn ConnRawAccess, sql string) (QueryHandle, error) {
+	var h QueryHandle
+	err := withImpalaConn(conn, func(c *isql.Conn) error {
+		ah, err := c.Submit(ctx, sql)
+		h = newQueryHandle(ah)
+		return err
+	})
+	return h, err
+}
+
+// Status returns the current status of the query. A failed query is not an error for Status.
+func (AsyncQuery) Status(ctx context.Context, conn ConnRawAccess, h QueryHandle) (QueryStatus, error) {
+	var status QueryStatus
+	err := withImpalaConn(conn, func(c *isql.Conn) error {
+		state, errMsg, err := c.AsyncStatus(ctx, h.asyncHandle())
+		status = QueryStatus{State: queryState(state), ErrorMessage: errMsg}
+		return err
+	})
+	return status, err
+}
+
+// Wait waits for the query to finish. It returns an error if the query fails or is cancelled,
+// or if ctx is done. Cancelling ctx doesn't cancel the query.
+func (AsyncQuery) Wait(ctx context.Context, conn ConnRawAccess, h QueryHandle) error {
+	return withImpalaConn(conn, func(c *isql.Conn) error {
+		return c.AsyncWait(ctx, h.asyncHandle())
+	})
+}
+
+// Cancel cancels the query. The query must still be closed with Close.
+func (AsyncQuery) Cancel(ctx context.Context, conn ConnRawAccess, h QueryHandle) error {
+	return withImpalaConn(conn, func(c *isql.Conn) error {
+		return c.AsyncCancel(ctx, h.asyncHandle())
+	})
+}
+
+// Results calls f with the result rows of the query. The rows must not be used after f returns.
+// Results can be fetched only once, because the server discards the rows that were fetched.
+func (AsyncQuery) Results(ctx context.Context, conn ConnRawAccess, h QueryHandle, f func(driver.Rows) error) error {
+	return withImpalaConn(conn, func(c *isql.Conn) error {
+		rows, err := c.AsyncResults(ctx, h.asyncHandle())
+		if err != nil {
+			return err
+		}
+		return errors.Join(f(rows), rows.Close())
+	})
+}
+
+// Close releases the query and its session on the server, cancelling the query if it is still running.
+// It returns the number of rows affected by DML queries.
+func (AsyncQuery) Close(ctx context.Context, conn ConnRawAccess, h QueryHandle) (int64, error) {
+	var rowsAffected int64
+	err := withImpalaConn(conn, func(c *isql.Conn) (err error) {
+		rowsAffected, err = c.AsyncClose(ctx, h.asyncHandle())
+		return err
+	})
+	return rowsAffected, err
+}
+
+// ExecMode selects if Exec methods wait for statements to finish
+type ExecMode string
+
+// Supported ExecMode values
+const (
+	// ExecModeSync makes Exec methods wait for statements to finish. This is the default.
+	ExecModeSync ExecMode = "sync"
+	// ExecModeAsync makes Exec methods return as soon as the server accepts the statement, which runs in its
+	// own session, like with AsyncQuery.Submit. WithAsyncExecHandleFunc receives the handle of the statement.
+	// By default, closing the connection leaves the statements running and Impala closes their sessions
+	// after its disconnected_session_timeout. With Options.AsyncExecCloseTimeout, closing the connection
+	// waits for the statements to finish, up to the timeout, and closes them.
+	ExecModeAsync ExecMode = "async"
+)
+
+func parseExecMode(val string) (ExecMode, error) {
+	switch mode := ExecMode(strings.ToLower(val)); mode {
+	case ExecModeSync, ExecModeAsync:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid exec-mode value: %s", val)
+	}
+}
+
+// WithExecMode returns a context that makes Exec methods run statements in the given mode,
+// overriding Options.ExecMode
+func WithExecMode(ctx context.Context, mode ExecMode) context.Context {
+	return isql.WithAsyncExec(ctx, mode == ExecModeAsync)
+}
+
+// AsyncExecHandleFunc receives the handle of a statement that an Exec method started in ExecModeAsync.
+// The handle can be used with AsyncQuery to wait for the statement, cancel it or get its rows affected with Close.
+type AsyncExecHandleFunc func(ctx context.Context, h QueryHandle)
+
+// WithAsyncExecHandleFunc returns a context that makes Exec methods pass the handles of statements
+// started in ExecModeAsync to fn. Statements that are closed with AsyncQuery.Close on a connection of
+// the same sql.DB are not closed again when the connection that started them closes.
+func WithAsyncExecHandleFunc(ctx context.Context, fn AsyncExecHandleFunc) context.Context {
+	if fn == nil {
+		return isql.WithAsyncHandleFunc(ctx, nil)
+	}
+	return isql.WithAsyncHandleFunc(ctx, func(ctx context.Context, h isql.AsyncHandle) {
+		fn(ctx, newQueryHandle(h))
+	})
+}
+
+func withImpalaConn(conn ConnRawAccess, f func(*isql.Conn) error) error {
+	return conn.Raw(func(driverConn any) error {
+		impalaConn, ok := driverConn.(*isql.Conn)
+		if !ok {
+			return errors.New("async queries can operate only on Impala drivers")
+		}
+		return f(impalaConn)
+	})
+}
+
+func newQueryHandle(h isql.AsyncHandle) QueryHandle {
+	if h.Session == nil || h.Operation == nil {
+		return QueryHandle{}
+	}
+	return QueryHandle{
+		SessionGUID:     h.Session.GetSessionId().GetGUID(),
+		SessionSecret:   h.Session.GetSessionId().GetSecret(),
+		OperationGUID:   h.Operation.GetOperationId().GetGUID(),
+		OperationSecret: h.Operation.GetOperationId().GetSecret(),
+		HasResultSet:    h.Operation.GetHasResultSet(),
+	}
+}
+
+func (h QueryHandle) asyncHandle() isql.AsyncHandle {
+	return isql.AsyncHandle{
+		Session: &cli_service.TSessionHandle{
+			SessionId: &cli_service.THandleIdentifier{GUID: h.SessionGUID, Secret: h.SessionSecret},
+		},
+		Operation: &cli_service.TOperationHandle{
+			OperationId:   &cli_service.THandleIdentifier{GUID: h.OperationGUID, Secret: h.OperationSecret},
+			OperationType: cli_service.TOperationType_EXECUTE_STATEMENT,
+			HasResultSet:  h.HasResultSet,
+		},
+	}
+}
+
+func queryState(state cli_service.TOperationState) QueryState {
+	switch state {
+	case cli_service.TOperationState_INITIALIZED_STATE, cli_service.TOperationState_PENDING_STATE:
+		return QueryStatePending
+	case cli_service.TOperationState_RUNNING_STATE:
+		return QueryStateRunning
+	case cli_service.TOperationState_FINISHED_STATE:
+		return QueryStateFinished
+	case cli_service.TOperationState_CANCELED_STATE:
+		return QueryStateCanceled
+	case cli_service.TOperationState_ERROR_STATE:
+		return QueryStateFailed
+	case cli_service.TOperationState_CLOSED_STATE:
+		return QueryStateClosed
+	}
+	return QueryStateUnknown
+}
@@ -0,0 +1,205 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncQuery(t *testing.T) {
+	ctx := context.Background()
+	newConn := func(t *testing.T, srvURL string) *sql.Conn {
+		db := sql.OpenDB(NewConnector(httpTestOptions(t, srvURL)))
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = conn.Close()
+			_ = db.Close()
+		})
+		return conn
+	}
+
+	t.Run("reattach from another connection", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1}
+		srv := httptest.NewServer(fake.httpHandler())
+		defer srv.Close()
+
+		var aq AsyncQuery
+		submitConn := newConn(t, srv.URL)
+		h, err := aq.Submit(ctx, submitConn, "INSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+		require.NoError(t, submitConn.Close())
+		// the query session outlives the connection
+		require.NotContains(t, fake.getCalls(), "CloseSession")
+
+		serialized, err := json.Marshal(h)
+		require.NoError(t, err)
+		var attached QueryHandle
+		require.NoError(t, json.Unmarshal(serialized, &attached))
+		require.Equal(t, h, attached)
+
+		conn := newConn(t, srv.URL)
+		status, err := aq.Status(ctx, conn, attached)
+		require.NoError(t, err)
+		require.Equal(t, QueryStatus{State: QueryStateRunning}, status)
+		require.False(t, status.Done())
+
+		require.NoError(t, aq.Wait(ctx, conn, attached))
+
+		var values []driver.Value
+		err = aq.Results(ctx, conn, attached, func(rows driver.Rows) error {
+			require.Equal(t, []string{"x"}, rows.Columns())
+			dest := make([]driver.Value, 1)
+			for rows.Next(dest) == nil {
+				values = append(values, dest[0])
+			}
+			require.Equal(t, io.EOF, rows.Next(dest))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []driver.Value{int32(1), int32(2)}, values)
+
+		_, err = aq.Close(ctx, conn, attached)
+		require.NoError(t, err)
+		require.Equal(t, 1, lo.Count(fake.getCalls(), "CloseOperation"))
+		require.Equal(t, 1, lo.Count(fake.getCalls(), "CloseSession"))
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 100}
+		srv := httptest.NewServer(fake.httpHandler())
+		defer srv.Close()
+
+		var aq AsyncQuery
+		conn := newConn(t, srv.URL)
+		h, err := aq.Submit(ctx, conn, "SELECT sleep(100000)")
+		require.NoError(t, err)
+		require.NoError(t, aq.Cancel(ctx, conn, h))
+
+		status, err := aq.Status(ctx, conn, h)
+		require.NoError(t, err)
+		require.Equal(t, QueryStateCanceled, status.State)
+		require.True(t, status.Done())
+		require.ErrorContains(t, aq.Wait(ctx, conn, h), "cancelled")
+
+		_, err = aq.Close(ctx, conn, h)
+		require.NoError(t, err)
+	})
+
+	t.Run("not an Impala connection", func(t *testing.T) {
+		_, err := AsyncQuery{}.Submit(ctx, rawFunc(func(f func(any) error) error { return f(nil) }), "SELECT 1")
+		require.ErrorContains(t, err, "only on Impala drivers")
+	})
+}
+
+// rawFunc adapts a function to ConnRawAccess
+type rawFunc func(func(driverConn any) error) error
+
+func (r rawFunc) Raw(f func(driverConn any) error) error {
+	return r(f)
+}
+
+func TestExecModeAsync(t *testing.T) {
+	ctx := context.Background()
+	const insert = "INSERT INTO t SELECT * FROM s"
+	start := func(t *testing.T, fake *fakeHS2, mode ExecMode, closeTimeout time.Duration) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.ExecMode = mode
+		opts.AsyncExecCloseTimeout = closeTimeout
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+
+	t.Run("wait and close with AsyncQuery", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1}
+		db := start(t, fake, ExecModeAsync, time.Minute)
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+
+		var h QueryHandle
+		execCtx := WithAsyncExecHandleFunc(ctx, func(_ context.Context, handle QueryHandle) { h = handle })
+		res, err := conn.ExecContext(execCtx, insert)
+		require.NoError(t, err)
+		require.NotContains(t, fake.getCalls(), "GetOperationStatus")
+		_, err = res.RowsAffected()
+		require.ErrorContains(t, err, "not known")
+		require.NotEmpty(t, h.QueryID())
+
+		// another connection of the same DB closes the statement
+		other, err := db.Conn(ctx)
+		require.NoError(t, err)
+		var aq AsyncQuery
+		require.NoError(t, aq.Wait(ctx, other, h))
+		_, err = aq.Close(ctx, other, h)
+		require.NoError(t, err)
+		require.NoError(t, other.Close())
+		require.NoError(t, conn.Close())
+		require.NoError(t, db.Close())
+		// the closed statement is not awaited again when the connection that started it closes
+		require.Equal(t, 1, lo.Count(fake.getCalls(), "CloseOperation"))
+	})
+
+	t.Run("close connection leaves statements running", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1000}
+		db := start(t, fake, ExecModeAsync, 0)
+		_, err := db.ExecContext(ctx, insert)
+		require.NoError(t, err)
+
+		require.NoError(t, db.Close())
+		calls := fake.getCalls()
+		require.NotContains(t, calls, "GetOperationStatus")
+		require.NotContains(t, calls, "CloseOperation")
+		// the dedicated session is left for the server to close. The connection didn't need its own session.
+		require.NotContains(t, calls, "CloseSession")
+	})
+
+	t.Run("close connection waits", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1}
+		db := start(t, fake, ExecModeSync, time.Minute)
+		_, err := db.ExecContext(WithExecMode(ctx, ExecModeAsync), insert)
+		require.NoError(t, err)
+		require.NotContains(t, fake.getCalls(), "GetOperationStatus")
+
+		require.NoError(t, db.Close())
+		calls := fake.getCalls()
+		require.Equal(t, 2, lo.Count(calls, "GetOperationStatus"))
+		require.Contains(t, calls, "CloseOperation")
+		require.NotContains(t, calls, "CancelOperation")
+		// the dedicated session of the statement. The connection didn't need its own session.
+		require.Equal(t, 1, lo.Count(calls, "CloseSession"))
+	})
+
+	t.Run("close connection cancels after timeout", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1000}
+		db := start(t, fake, ExecModeAsync, 50*time.Millisecond)
+		_, err := db.ExecContext(ctx, insert)
+		require.NoError(t, err)
+
+		require.NoError(t, db.Close())
+		calls := fake.getCalls()
+		require.Contains(t, calls, "CancelOperation")
+		require.Contains(t, calls, "CloseOperation")
+	})
+
+	t.Run("sync override", func(t *testing.T) {
+		fake := &fakeHS2{}
+		db := start(t, fake, ExecModeAsync, 0)
+		execCtx := WithAsyncExecHandleFunc(WithExecMode(ctx, ExecModeSync), func(context.Context, QueryHandle) {
+			require.Fail(t, "the statement is not async")
+		})
+		_, err := db.ExecContext(execCtx, insert)
+		require.NoError(t, err)
+		require.Contains(t, fake.getCalls(), "GetOperationStatus")
+	})
+}
@@ -0,0 +1,38 @@
+package impala
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveBatchSize(t *testing.T) {
+	fake := &fakeHS2{batches: 5}
+	srv := httptest.NewServer(fake.httpHandler())
+	t.Cleanup(srv.Close)
+	opts := httpTestOptions(t, srv.URL)
+	opts.BatchSize = 1000
+	// 1000 INT values and their null bitmap take 4125 bytes
+	opts.BatchTargetBytes = 400
+	var logOut bytes.Buffer
+	opts.LogOut = &logOut
+	db := sql.OpenDB(NewConnector(opts))
+	t.Cleanup(func() { _ = db.Close() })
+
+	rows, err := db.QueryContext(context.Background(), "SELECT x FROM t")
+	require.NoError(t, err)
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 1000+4*96, n)
+	require.Contains(t, logOut.String(), "adaptive batch size, starting with 1000 rows (min 16, max 65536, target 400 bytes)")
+	require.Contains(t, logOut.String(), "fetched 1000 rows, 4125 bytes in ")
+	require.Contains(t, logOut.String(), "fetching up to 96 rows per batch")
+}
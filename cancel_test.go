@@ -0,0 +1,59 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextCancel_ServerSide(t *testing.T) {
+	start := func(t *testing.T, fake *fakeHS2) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		db := sql.OpenDB(NewConnector(httpTestOptions(t, srv.URL)))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+
+	t.Run("exec", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1000}
+		db := start(t, fake)
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "server confirmed the query was cancelled")
+		require.Subset(t, fake.getCalls(), []string{"CancelOperation", "CloseOperation"})
+	})
+
+	t.Run("query", func(t *testing.T) {
+		fake := &fakeHS2{fetchPending: true}
+		db := start(t, fake)
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		rows, err := db.QueryContext(ctx, "SELECT * FROM t")
+		require.NoError(t, err)
+		require.False(t, rows.Next())
+		require.ErrorIs(t, rows.Err(), context.DeadlineExceeded)
+		require.NoError(t, rows.Close())
+		require.Subset(t, fake.getCalls(), []string{"CancelOperation", "CloseOperation"})
+	})
+
+	t.Run("cancel fails", func(t *testing.T) {
+		fake := &fakeHS2{runningPolls: 1000, cancelFails: true}
+		db := start(t, fake)
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "failed to cancel the query on the server: remote server error")
+		require.Contains(t, fake.getCalls(), "CloseOperation")
+	})
+}
@@ -0,0 +1,79 @@
+package impala
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// clientCertReloader loads the client certificate for mutual TLS and reloads it
+// if the certificate or key file is modified, so rotated certificates are picked up by new handshakes.
+type clientCertReloader struct {
+	certPath string
+	keyPath  string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	certTime time.Time
+	keyTime  time.Time
+}
+
+func newClientCertReloader(certPath string, keyPath string) (*clientCertReloader, error) {
+	if certPath == "" || keyPath == "" {
+		return nil, errors.New("both client certificate and key must be provided")
+	}
+	r := &clientCertReloader{
+		certPath: certPath,
+		keyPath:  keyPath,
+	}
+	if _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// getClientCertificate implements tls.Config.GetClientCertificate
+func (r *clientCertReloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.load()
+}
+
+func (r *clientCertReloader) load() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	certTime, certErr := modTime(r.certPath)
+	keyTime, keyErr := modTime(r.keyPath)
+	if err := errors.Join(certErr, keyErr); err != nil {
+		if r.cert != nil {
+			// The files may be temporarily missing during rotation - keep using the last good certificate
+			return r.cert, nil
+		}
+		return nil, err
+	}
+	if r.cert != nil && certTime.Equal(r.certTime) && keyTime.Equal(r.keyTime) {
+		return r.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		if r.cert != nil {
+			// The certificate and key may be temporarily mismatched during rotation
+			return r.cert, nil
+		}
+		return nil, err
+	}
+	r.cert = &cert
+	r.certTime = certTime
+	r.keyTime = keyTime
+	return r.cert, nil
+}
+
+func modTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,187 @@
+package impala
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/murfffi/gorich/fi"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCertPath = "compose/testssl/localhost.crt"
+	testKeyPath  = "compose/testssl/localhost.key"
+)
+
+func TestMutualTLS(t *testing.T) {
+	t.Run("client cert", func(t *testing.T) {
+		port, peers := startTLSServer(t, tls.RequireAndVerifyClientCert)
+		opts := DefaultOptions
+		opts.Host = "localhost"
+		opts.Port = strconv.Itoa(port)
+		opts.UseTLS = true
+		opts.CACertPath = testCertPath
+		opts.ClientCertPath = testCertPath
+		opts.ClientKeyPath = testKeyPath
+		opts.TLSMinVersion = tls.VersionTLS13
+
+		conn, err := connect(context.Background(), &opts, nil)
+		require.NoError(t, err)
+		defer fi.NoErrorF(conn.Close, t)
+		certs := <-peers
+		require.Len(t, certs, 1)
+		require.Equal(t, "murfffi", certs[0].Subject.CommonName)
+	})
+
+	t.Run("server name override", func(t *testing.T) {
+		port, peers := startTLSServer(t, tls.NoClientCert)
+		opts := DefaultOptions
+		opts.Host = "127.0.0.1" // not in the certificate
+		opts.Port = strconv.Itoa(port)
+		opts.UseTLS = true
+		opts.CACertPath = testCertPath
+		opts.TLSServerName = "localhost"
+
+		conn, err := connect(context.Background(), &opts, nil)
+		require.NoError(t, err)
+		defer fi.NoErrorF(conn.Close, t)
+		require.Empty(t, <-peers)
+	})
+
+	t.Run("tls config as-is", func(t *testing.T) {
+		port, peers := startTLSServer(t, tls.RequestClientCert)
+		opts := DefaultOptions
+		opts.Host = "localhost"
+		opts.Port = strconv.Itoa(port)
+		opts.UseTLS = true
+		opts.ClientCertPath = "ignored"
+		opts.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+
+		conn, err := connect(context.Background(), &opts, nil)
+		require.NoError(t, err)
+		defer fi.NoErrorF(conn.Close, t)
+		require.Empty(t, <-peers)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		opts := DefaultOptions
+		opts.UseTLS = true
+		opts.ClientCertPath = testCertPath
+		_, err := getTLSConfig(&opts)
+		require.ErrorIs(t, err, ErrBadDSN)
+	})
+}
+
+func TestClientCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "client.crt")
+	keyPath := filepath.Join(dir, "client.key")
+	writeTestCert(t, certPath, keyPath, 1)
+
+	reloader, err := newClientCertReloader(certPath, keyPath)
+	require.NoError(t, err)
+	first, err := reloader.getClientCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), first.Leaf.SerialNumber.Int64())
+
+	writeTestCert(t, certPath, keyPath, 2)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certPath, later, later))
+	second, err := reloader.getClientCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), second.Leaf.SerialNumber.Int64())
+
+	require.NoError(t, os.Remove(keyPath))
+	third, err := reloader.getClientCertificate(nil)
+	require.NoError(t, err)
+	require.Same(t, second, third)
+}
+
+func TestSharedClientCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	opts := DefaultOptions
+	opts.UseTLS = true
+	opts.ClientCertPath = filepath.Join(dir, "client.crt")
+	opts.ClientKeyPath = filepath.Join(dir, "client.key")
+	writeTestCert(t, opts.ClientCertPath, opts.ClientKeyPath, 1)
+	c := NewConnector(&opts).(*connector)
+
+	first, err := c.clientCertReloader()
+	require.NoError(t, err)
+	firstCert, err := first.getClientCertificate(nil)
+	require.NoError(t, err)
+
+	// the certificate loaded by earlier connections of the connector is used while the files are rotated
+	require.NoError(t, os.Remove(opts.ClientKeyPath))
+	second, err := c.clientCertReloader()
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	connOpts := opts
+	connOpts.clientCert = second
+	tlsConfig, err := getTLSConfig(&connOpts)
+	require.NoError(t, err)
+	secondCert, err := tlsConfig.GetClientCertificate(nil)
+	require.NoError(t, err)
+	require.Same(t, firstCert, secondCert)
+
+	// other connectors load the certificate themselves
+	_, err = NewConnector(&opts).(*connector).clientCertReloader()
+	require.ErrorIs(t, err, ErrBadDSN)
+}
+
+func startTLSServer(t *testing.T, clientAuth tls.ClientAuthType) (int, chan []*x509.Certificate) {
+	cert, err := tls.LoadX509KeyPair(testCertPath, testKeyPath)
+	require.NoError(t, err)
+	pool, err := readCert(testCertPath)
+	require.NoError(t, err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   clientAuth,
+		ClientCAs:    pool,
+	})
+	require.NoError(t, err)
+	fi.CleanupF(t, listener.Close)
+	peers := make(chan []*x509.Certificate, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		tlsConn := conn.(*tls.Conn)
+		if tlsConn.Handshake() == nil {
+			peers <- tlsConn.ConnectionState().PeerCertificates
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, peers
+}
+
+func writeTestCert(t *testing.T, certPath string, keyPath string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0o600))
+}
@@ -0,0 +1,95 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sclgo/impala-go/internal/hive"
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// ColumnBatch is a batch of rows of a query result in columnar form. See QueryColumns.
+type ColumnBatch = hive.Batch
+
+// Vector is a column of a ColumnBatch with values of type T and a null bitmap
+type Vector[T any] = hive.Vector[T]
+
+// QueryColumns runs query with args on conn and calls f with the rows of the result, in batches in columnar form
+// as they are fetched. args are bound to placeholders like in sql.Conn.QueryContext.
+// Each column of a batch is a Vector taken as is from the server response, e.g. a Vector[int64]
+// for a BIGINT column; see ColumnBatch.Columns for all types. Reading large results this way is much faster
+// than with sql.Rows, because the values are not converted to driver.Value one by one.
+// The batches must not be used after f returns. If f returns an error, the query is closed and the error is returned.
+//
+// *sql.Conn implements ConnRawAccess.
+func QueryColumns(ctx context.Context, conn ConnRawAccess, query string, f func(batch *ColumnBatch) error, args ...any) error {
+	return conn.Raw(func(driverConn any) error {
+		impalaConn, ok := driverConn.(*isql.Conn)
+		if !ok {
+			return errors.New("columnar queries can operate only on Impala drivers")
+		}
+		rows, err := queryRows(ctx, impalaConn, query, args)
+		if err != nil {
+			return err
+		}
+		return errors.Join(eachBatch(rows, f), rows.Close())
+	})
+}
+
+// queryRows runs query with args on conn and returns its rows
+func queryRows(ctx context.Context, conn *isql.Conn, query string, args []any) (*isql.Rows, error) {
+	namedArgs, err := namedValues(conn, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, query, namedArgs)
+	if err != nil {
+		return nil, err
+	}
+	impalaRows, ok := rows.(*isql.Rows)
+	if !ok {
+		_ = rows.Close()
+		return nil, fmt.Errorf("impala: unexpected driver rows %T", rows)
+	}
+	return impalaRows, nil
+}
+
+// namedValues converts args for conn like database/sql does: sql.NamedArg values become named arguments
+// and values that conn doesn't check are converted by driver.DefaultParameterConverter
+func namedValues(conn *isql.Conn, args []any) ([]driver.NamedValue, error) {
+	namedArgs := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		nv := driver.NamedValue{Ordinal: i + 1, Value: arg}
+		if named, ok := arg.(sql.NamedArg); ok {
+			nv.Name, nv.Value = named.Name, named.Value
+		}
+		err := conn.CheckNamedValue(&nv)
+		if errors.Is(err, driver.ErrSkip) {
+			nv.Value, err = driver.DefaultParameterConverter.ConvertValue(nv.Value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("converting argument %d: %w", nv.Ordinal, err)
+		}
+		namedArgs[i] = nv
+	}
+	return namedArgs, nil
+}
+
+func eachBatch(rows *isql.Rows, f func(batch *ColumnBatch) error) error {
+	for {
+		batch, err := rows.NextBatch()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = f(batch); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryColumns(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeHS2{batches: 3}
+	db := startPrefetch(t, fake, 4, 0)
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var values []int32
+	err = QueryColumns(ctx, conn, "SELECT x FROM t", func(batch *ColumnBatch) error {
+		require.Equal(t, []string{"x"}, batch.Names)
+		require.Equal(t, 4, batch.Len)
+		col := batch.Columns[0].(Vector[int32])
+		require.False(t, col.IsNull(0))
+		values = append(values, col.Values...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, lo.Range(12), lo.Map(values, func(v int32, _ int) int { return int(v) }))
+	require.Equal(t, "CloseOperation", lo.LastOrEmpty(fake.getCalls()))
+
+	err = QueryColumns(ctx, conn, "SELECT x FROM t WHERE y = ? AND z = @z", func(*ColumnBatch) error { return nil },
+		"it's", sql.Named("z", int8(-1)))
+	require.NoError(t, err)
+	require.Equal(t, `SELECT x FROM t WHERE y = 'it\'s' AND z = (-1)`, lo.LastOrEmpty(fake.getStatements()))
+
+	err = QueryColumns(ctx, conn, "SELECT ?", func(*ColumnBatch) error { return nil }, struct{}{})
+	require.ErrorContains(t, err, "converting argument 1")
+
+	stop := errors.New("stop")
+	err = QueryColumns(ctx, conn, "SELECT x FROM t", func(*ColumnBatch) error { return stop })
+	require.ErrorIs(t, err, stop)
+	require.Equal(t, "CloseOperation", lo.LastOrEmpty(fake.getCalls()))
+	require.NoError(t, conn.PingContext(ctx))
+}
@@ -0,0 +1,84 @@
+package impala
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"maps"
+
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// DMLResult is the detailed result of a DML statement such as INSERT, UPSERT, UPDATE or DELETE
+type DMLResult struct {
+	// RowsModified is the number of inserted or updated rows per partition. Only HDFS and Kudu tables report it.
+	// Partitions are keyed as k1=v1/k2=v2, and the key of an unpartitioned table is the empty string.
+	RowsModified map[string]int64
+	// RowsDeleted is the number of deleted rows per partition, keyed like RowsModified
+	RowsDeleted map[string]int64
+	// RowErrors is the number of row operations that Kudu didn't complete because of non-fatal errors,
+	// such as duplicate or missing primary keys. Impala reports these only as warnings.
+	RowErrors int64
+}
+
+// TotalModified returns the number of modified rows in all partitions
+func (r *DMLResult) TotalModified() int64 {
+	return sum(r.RowsModified)
+}
+
+// TotalDeleted returns the number of deleted rows in all partitions
+func (r *DMLResult) TotalDeleted() int64 {
+	return sum(r.RowsDeleted)
+}
+
+// DMLResultFunc receives the detailed result of a DML statement that an Exec method ran
+type DMLResultFunc func(ctx context.Context, res *DMLResult)
+
+// WithDMLResultFunc returns a context that makes Exec methods pass the detailed results of DML statements to fn.
+// For multi-statement queries, fn receives the totals of all statements. Other statements are not reported.
+func WithDMLResultFunc(ctx context.Context, fn DMLResultFunc) context.Context {
+	if fn == nil {
+		return isql.WithDMLResultFunc(ctx, nil)
+	}
+	return isql.WithDMLResultFunc(ctx, func(ctx context.Context, dml *impalaservice.TDmlResult_) {
+		fn(ctx, newDMLResult(dml))
+	})
+}
+
+// DMLResultOf returns the detailed result of a DML statement from the driver result of an Exec method.
+// database/sql doesn't expose the driver result behind sql.Result, so res must come from a driver connection,
+// e.g. in sql.Conn.Raw. It returns false if res is not a result of a DML statement run by this driver.
+func DMLResultOf(res driver.Result) (*DMLResult, bool) {
+	r, ok := res.(*isql.Result)
+	if !ok || r.DML == nil {
+		return nil, false
+	}
+	return newDMLResult(r.DML), true
+}
+
+// DMLResultFromError returns the result of a DML statement that failed with ErrRowErrors because of
+// Options.FailOnRowErrors. The statement changed the rows that it didn't report as errors.
+func DMLResultFromError(err error) (*DMLResult, bool) {
+	var rowErr *isql.RowErrorsError
+	if errors.As(err, &rowErr) {
+		return newDMLResult(rowErr.Result), true
+	}
+	return nil, false
+}
+
+func newDMLResult(dml *impalaservice.TDmlResult_) *DMLResult {
+	return &DMLResult{
+		RowsModified: maps.Clone(dml.GetRowsModified()),
+		RowsDeleted:  maps.Clone(dml.GetRowsDeleted()),
+		RowErrors:    dml.GetNumRowErrors(),
+	}
+}
+
+func sum(m map[string]int64) int64 {
+	var total int64
+	for _, v := range m {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,104 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMLResult(t *testing.T) {
+	ctx := context.Background()
+	dml := &impalaservice.TDmlResult_{
+		RowsModified: map[string]int64{"year=2024": 3, "year=2025": 4},
+		RowsDeleted:  map[string]int64{"year=2023": 2},
+		NumRowErrors: thrift.Int64Ptr(5),
+	}
+	start := func(t *testing.T, fake *fakeHS2, failOnRowErrors bool) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.FailOnRowErrors = failOnRowErrors
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+
+	t.Run("result", func(t *testing.T) {
+		db := start(t, &fakeHS2{dmlResult: dml}, false)
+		var dmlRes *DMLResult
+		execCtx := WithDMLResultFunc(ctx, func(_ context.Context, res *DMLResult) { dmlRes = res })
+		res, err := db.ExecContext(execCtx, "UPSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+		rowsAffected, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(9), rowsAffected)
+		_, err = res.LastInsertId()
+		require.Error(t, err)
+
+		require.Equal(t, &DMLResult{
+			RowsModified: map[string]int64{"year=2024": 3, "year=2025": 4},
+			RowsDeleted:  map[string]int64{"year=2023": 2},
+			RowErrors:    5,
+		}, dmlRes)
+		require.Equal(t, int64(7), dmlRes.TotalModified())
+		require.Equal(t, int64(2), dmlRes.TotalDeleted())
+	})
+
+	t.Run("driver result", func(t *testing.T) {
+		db := start(t, &fakeHS2{dmlResult: dml}, false)
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		err = conn.Raw(func(driverConn any) error {
+			res, err := driverConn.(driver.ExecerContext).ExecContext(ctx, "UPSERT INTO t SELECT * FROM s", nil)
+			if err != nil {
+				return err
+			}
+			dmlRes, ok := DMLResultOf(res)
+			require.True(t, ok)
+			require.Equal(t, int64(7), dmlRes.TotalModified())
+			require.Equal(t, int64(5), dmlRes.RowErrors)
+			return nil
+		})
+		require.NoError(t, err)
+		_, ok := DMLResultOf(driver.RowsAffected(1))
+		require.False(t, ok)
+	})
+
+	t.Run("not DML", func(t *testing.T) {
+		db := start(t, &fakeHS2{}, false)
+		execCtx := WithDMLResultFunc(ctx, func(context.Context, *DMLResult) {
+			require.Fail(t, "the statement is not DML")
+		})
+		_, err := db.ExecContext(execCtx, "CREATE TABLE t (x INT)")
+		require.NoError(t, err)
+	})
+
+	t.Run("fail on row errors", func(t *testing.T) {
+		db := start(t, &fakeHS2{dmlResult: dml}, true)
+		_, err := db.ExecContext(ctx, "UPSERT INTO t SELECT * FROM s")
+		require.ErrorIs(t, err, ErrRowErrors)
+		require.ErrorContains(t, err, "5 row operations failed")
+		_, ok := QueryIDFromError(err)
+		require.True(t, ok)
+		dmlRes, ok := DMLResultFromError(err)
+		require.True(t, ok)
+		require.Equal(t, int64(5), dmlRes.RowErrors)
+	})
+
+	t.Run("no row errors", func(t *testing.T) {
+		db := start(t, &fakeHS2{dmlResult: &impalaservice.TDmlResult_{RowsModified: map[string]int64{"": 1}}}, true)
+		var dmlRes *DMLResult
+		execCtx := WithDMLResultFunc(ctx, func(_ context.Context, res *DMLResult) { dmlRes = res })
+		_, err := db.ExecContext(execCtx, "INSERT INTO t VALUES (1)")
+		require.NoError(t, err)
+		require.NotNil(t, dmlRes)
+		require.Equal(t, map[string]int64{"": 1}, dmlRes.RowsModified)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
@@ -33,7 +34,16 @@ var (
 	// Following database/sql docs, this is a separate error from driver.ErrBadConn.
 	// If the root cause is context.DeadlineExceeded, AuthError, or *tls.CertificateVerificationError,
 	// that cause will be in the same error tree as this sentinel, likely as a sibling.
-	ErrOpenFailed = errors.New("impala: failed to open connection")
+	// ErrOpenFailed is also returned by query methods if the server doesn't accept the session configuration.
+	ErrOpenFailed = isql.ErrOpenFailed
+
+	// ErrUnknownQueryOption is returned with Options.StrictSessionConfig for statements with query options,
+	// set by WithQueryOptions, that the server doesn't know.
+	ErrUnknownQueryOption = hive.ErrUnknownQueryOption
+
+	// ErrRowErrors is returned with Options.FailOnRowErrors for DML statements that report row errors.
+	// DMLResultFromError returns the result of such statements.
+	ErrRowErrors = isql.ErrRowErrors
 
 	// ErrBadDSN means the driver failed to parse the DSN or contained incorrect values.
 	// Another error in the tree will describe the specific issue.
@@ -75,7 +85,7 @@ func (*Driver) Open(dsn string) (driver.Conn, error) {
 		return nil, fmt.Errorf("%w: %w", ErrBadDSN, err)
 	}
 
-	conn, err := connect(context.Background(), opts)
+	conn, err := connect(context.Background(), opts, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -83,20 +93,26 @@ func (*Driver) Open(dsn string) (driver.Conn, error) {
 }
 
 func parseURI(uri string) (*Options, error) {
+	uri, hosts := extractHostList(uri)
 	u, err := url.Parse(uri)
 	if err != nil {
 		return nil, err
 	}
 
-	// #21, https and http will be supported in the future for http transport
-	// transport=http(s) will also be supported for usql/dburl compatibility
+	opts := DefaultOptions
 
-	if u.Scheme != "impala" {
+	// http and https schemes, as well as transport=http(s), are recognized for usql/dburl compatibility
+	switch u.Scheme {
+	case "impala":
+	case "impala+http", "http":
+		opts.UseHTTP = true
+	case "impala+https", "https":
+		opts.UseHTTP = true
+		opts.UseTLS = true
+	default:
 		return nil, fmt.Errorf("scheme %s not recognized", u.Scheme)
 	}
 
-	opts := DefaultOptions
-
 	if u.User != nil {
 		opts.Username = u.User.Username()
 		password, ok := u.User.Password()
@@ -107,12 +123,77 @@ func parseURI(uri string) (*Options, error) {
 
 	opts.Host = u.Hostname()
 	opts.Port = u.Port()
+	opts.Database = strings.TrimPrefix(u.Path, "/")
+	if strings.Contains(opts.Database, "/") {
+		return nil, fmt.Errorf("invalid database %s: the URL path must have a single segment", opts.Database)
+	}
+
+	if hosts != nil {
+		// Each entry keeps its own port. The port of the first entry is not a default for the rest.
+		opts.Host = ""
+		opts.Port = ""
+		for _, h := range hosts {
+			if h == "" {
+				return nil, errors.New("empty host in host list")
+			}
+		}
+		opts.Hosts = hosts
+	}
+
+	query := u.Query()
+
+	transport, ok := query["transport"]
+	if ok {
+		switch strings.ToLower(transport[0]) {
+		case "binary":
+			opts.UseHTTP = false
+		case "http":
+			opts.UseHTTP = true
+		case "https":
+			opts.UseHTTP = true
+			opts.UseTLS = true
+		default:
+			return nil, fmt.Errorf("invalid transport value: %s", transport[0])
+		}
+	}
 
 	if opts.Port == "" {
 		opts.Port = DefaultOptions.Port
+		if opts.UseHTTP {
+			opts.Port = DefaultHTTPPort
+		}
 	}
 
-	query := u.Query()
+	hostSelection, ok := query["host-selection"]
+	if ok {
+		opts.HostSelection, err = parseHostSelection(hostSelection[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = parseDurationKey(query, "host-quarantine", &opts.HostQuarantine)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, ok := query["proxy"]
+	if ok {
+		if _, err = parseProxyURL(proxy[0]); err != nil {
+			return nil, err
+		}
+		opts.Proxy = proxy[0]
+	}
+
+	httpPath, ok := query["http-path"]
+	if ok {
+		opts.HTTPPath = httpPath[0]
+	}
+
+	impersonate, ok := query["impersonate"]
+	if ok {
+		opts.ImpersonateUser = impersonate[0]
+	}
 
 	err = parseBoolKey(query, "reuse-session", &opts.ReuseSession)
 	if err != nil {
@@ -120,8 +201,21 @@ func parseURI(uri string) (*Options, error) {
 	}
 
 	auth := query.Get("auth")
-	if auth == "ldap" {
+	switch auth {
+	case "ldap":
 		opts.UseLDAP = true
+	case "kerberos":
+		opts.UseKerberos = true
+	}
+
+	krb5Service, ok := query["krb5-service"]
+	if ok {
+		opts.KerberosService = krb5Service[0]
+	}
+
+	krb5Principal, ok := query["krb5-principal"]
+	if ok {
+		opts.KerberosPrincipal = krb5Principal[0]
 	}
 
 	err = parseBoolKey(query, "tls", &opts.UseTLS)
@@ -139,6 +233,26 @@ func parseURI(uri string) (*Options, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		clientCert, ok := query["client-cert"]
+		if ok {
+			opts.ClientCertPath = clientCert[0]
+		}
+
+		clientKey, ok := query["client-key"]
+		if ok {
+			opts.ClientKeyPath = clientKey[0]
+		}
+
+		serverName, ok := query["tls-server-name"]
+		if ok {
+			opts.TLSServerName = serverName[0]
+		}
+
+		err = parseTLSVersionKey(query, "tls-min-version", &opts.TLSMinVersion)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	err = parseIntKey(query, "batch-size", &opts.BatchSize)
@@ -151,6 +265,31 @@ func parseURI(uri string) (*Options, error) {
 		return nil, err
 	}
 
+	err = parseIntKey(query, "prefetch", &opts.Prefetch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseIntKey(query, "batch-target-bytes", &opts.BatchTargetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseIntKey(query, "batch-size-min", &opts.BatchSizeMin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseIntKey(query, "batch-size-max", &opts.BatchSizeMax)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseDurationKey(query, "batch-max-fetch-time", &opts.BatchMaxFetchTime)
+	if err != nil {
+		return nil, err
+	}
+
 	memLimit, ok := query["mem-limit"]
 	if ok {
 		opts.MemoryLimit = memLimit[0]
@@ -171,6 +310,50 @@ func parseURI(uri string) (*Options, error) {
 		return nil, err
 	}
 
+	err = parseBoolKey(query, "strict-session-config", &opts.StrictSessionConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseBoolKey(query, "profile-on-error", &opts.ProfileOnError)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseBoolKey(query, "fail-on-row-errors", &opts.FailOnRowErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	err = parseBoolKey(query, "multi-statements", &opts.MultiStatements)
+	if err != nil {
+		return nil, err
+	}
+
+	execMode, ok := query["exec-mode"]
+	if ok {
+		opts.ExecMode, err = parseExecMode(execMode[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for key, values := range query {
+		name, ok := strings.CutPrefix(key, "set.")
+		if !ok {
+			name, ok = strings.CutPrefix(key, "query-option.")
+		}
+		if ok {
+			if name == "" {
+				return nil, fmt.Errorf("invalid %s: missing query option name", key)
+			}
+			if opts.SessionConfig == nil {
+				opts.SessionConfig = make(map[string]string)
+			}
+			opts.SessionConfig[name] = values[0]
+		}
+	}
+
 	logDest, ok := query["log"]
 	if ok {
 		if strings.ToLower(logDest[0]) == "stderr" {
@@ -194,6 +377,25 @@ func parseBoolKey(query url.Values, key string, dest *bool) error {
 	return nil
 }
 
+func parseTLSVersionKey(query url.Values, key string, target *uint16) error {
+	values, ok := query[key]
+	if ok {
+		switch values[0] {
+		case "1.0":
+			*target = tls.VersionTLS10
+		case "1.1":
+			*target = tls.VersionTLS11
+		case "1.2":
+			*target = tls.VersionTLS12
+		case "1.3":
+			*target = tls.VersionTLS13
+		default:
+			return fmt.Errorf("invalid %s: %s - supported values are 1.0, 1.1, 1.2, 1.3", key, values[0])
+		}
+	}
+	return nil
+}
+
 func parseDurationKey(query url.Values, key string, target *time.Duration) (err error) {
 	values, ok := query[key]
 	if ok {
@@ -229,11 +431,17 @@ func (*Driver) OpenConnector(name string) (driver.Connector, error) {
 		return nil, err
 	}
 
-	return &connector{opts: opts}, nil
+	return &connector{opts: opts, pending: &isql.PendingStatements{}}, nil
 }
 
 type connector struct {
 	opts *Options
+	// pending are the statements that connections started in ExecModeAsync and didn't close yet
+	pending *isql.PendingStatements
+
+	mu sync.Mutex
+	// clientCert is the reloader of the client certificate that the connections share, once loaded
+	clientCert *clientCertReloader
 }
 
 // NewConnector creates a connector with specified options.
@@ -243,14 +451,38 @@ type connector struct {
 // If needed, users can wrap the connector to implement custom
 // features e.g., statements to initialize connections.
 func NewConnector(opts *Options) driver.Connector {
-	return &connector{opts: opts}
+	return &connector{opts: opts, pending: &isql.PendingStatements{}}
 }
 
 // Connect implements driver.Connector
 //
 // See Driver.Open for details about error results.
 func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
-	return connect(ctx, c.opts)
+	reloader, err := c.clientCertReloader()
+	if err != nil {
+		return nil, err
+	}
+	opts := *c.opts
+	opts.clientCert = reloader
+	return connect(ctx, &opts, c.pending)
+}
+
+// clientCertReloader returns the reloader of the client certificate, if the connections use one, so that
+// they share the loaded certificate and keep using it if a reload fails. It is loaded on first use.
+func (c *connector) clientCertReloader() (*clientCertReloader, error) {
+	if !c.opts.UseTLS || c.opts.TLSConfig != nil || (c.opts.ClientCertPath == "" && c.opts.ClientKeyPath == "") {
+		return nil, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.clientCert == nil {
+		reloader, err := newClientCertReloader(c.opts.ClientCertPath, c.opts.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read client certificate: %w", ErrBadDSN, err)
+		}
+		c.clientCert = reloader
+	}
+	return c.clientCert, nil
 }
 
 // Driver implements driver.Connector
@@ -258,30 +490,90 @@ func (c *connector) Driver() driver.Driver {
 	return (*Driver)(nil) // Driver methods work on a nil reference
 }
 
-func connect(ctx context.Context, opts *Options) (*isql.Conn, error) {
+func connect(ctx context.Context, opts *Options, pending *isql.PendingStatements) (*isql.Conn, error) {
 	if opts.LogOut == nil {
 		opts.LogOut = io.Discard
 	}
-	transport, tclient, err := connectThrift(ctx, opts)
+	logger := log.New(opts.LogOut, "impala: ", log.LstdFlags)
+	transport, client, err := dial(ctx, opts, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	logger := log.New(opts.LogOut, "impala: ", log.LstdFlags)
-	client := hive.NewClient(tclient, logger, &hive.Options{
-		MaxRows:      int64(opts.BatchSize),
-		MemLimit:     opts.MemoryLimit,
-		QueryTimeout: opts.QueryTimeout,
-	})
-
 	return isql.NewConn(client, transport, logger, isql.Options{
-		ReuseSession: opts.ReuseSession,
+		ReuseSession:          opts.ReuseSession,
+		ImpersonateUser:       opts.ImpersonateUser,
+		ProfileOnError:        opts.ProfileOnError,
+		FailOnRowErrors:       opts.FailOnRowErrors,
+		ProgressFunc:          hiveProgressFunc(opts.ProgressFunc),
+		QueryLogFunc:          isqlQueryLogFunc(opts.QueryLogFunc),
+		MultiStatements:       opts.MultiStatements,
+		AsyncExec:             opts.ExecMode == ExecModeAsync,
+		AsyncExecCloseTimeout: opts.AsyncExecCloseTimeout,
+		Pending:               pending,
+		Retry:                 isqlRetryPolicy(opts, logger),
 	}), nil
 }
 
-func openTransport(ctx context.Context, opts *Options) (thrift.TTransport, *thrift.TConfiguration, error) {
+// batchSizing returns the adaptive batch sizing selected by opts, or nil
+func batchSizing(opts *Options) (*hive.BatchSizing, error) {
+	if opts.BatchTargetBytes <= 0 {
+		return nil, nil
+	}
+	minRows := lo.CoalesceOrEmpty(opts.BatchSizeMin, 16)
+	maxRows := lo.CoalesceOrEmpty(opts.BatchSizeMax, 65536)
+	if minRows > maxRows {
+		return nil, fmt.Errorf("%w: batch-size-min %d is greater than batch-size-max %d", ErrBadDSN, minRows, maxRows)
+	}
+	return &hive.BatchSizing{
+		MinRows:      int64(minRows),
+		MaxRows:      int64(maxRows),
+		TargetBytes:  int64(opts.BatchTargetBytes),
+		MaxFetchTime: lo.CoalesceOrEmpty(opts.BatchMaxFetchTime, opts.SocketTimeout/2),
+	}, nil
+}
+
+// dial opens a transport and a client on it
+func dial(ctx context.Context, opts *Options, logger *log.Logger) (thrift.TTransport, *hive.Client, error) {
+	sizing, err := batchSizing(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	transport, tclient, err := connectThrift(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client := hive.NewClient(tclient, logger, &hive.Options{
+		MaxRows:             int64(opts.BatchSize),
+		Prefetch:            opts.Prefetch,
+		BatchSizing:         sizing,
+		MemLimit:            opts.MemoryLimit,
+		QueryTimeout:        opts.QueryTimeout,
+		SessionConfig:       opts.SessionConfig,
+		StrictSessionConfig: opts.StrictSessionConfig,
+		Database:            opts.Database,
+	})
+	return transport, client, nil
+}
+
+func openTransport(ctx context.Context, opts *Options, addr address) (thrift.TTransport, *thrift.TConfiguration, error) {
 	var err error
-	hostPort := net.JoinHostPort(opts.Host, opts.Port)
+	hostPort := addr.String()
+
+	if opts.UseLDAP && opts.Username == "" {
+		// Empty password will be used if not provided.
+		return nil, nil, fmt.Errorf("%w: provide username for LDAP auth", ErrBadDSN)
+	}
+
+	if opts.UseKerberos {
+		if opts.UseLDAP {
+			return nil, nil, fmt.Errorf("%w: LDAP and Kerberos auth can't be enabled together", ErrBadDSN)
+		}
+		if opts.UseHTTP {
+			return nil, nil, fmt.Errorf("%w: Kerberos auth is not supported with HTTP transport", ErrBadDSN)
+		}
+	}
 
 	conf := &thrift.TConfiguration{
 		TBinaryStrictRead:  lo.ToPtr(false),
@@ -290,71 +582,83 @@ func openTransport(ctx context.Context, opts *Options) (thrift.TTransport, *thri
 		ConnectTimeout:     opts.ConnectTimeout,
 	}
 
+	if opts.UseHTTP {
+		// with several hosts, an unreachable host must fail now, so that the next one is tried
+		transport, err := openHTTPTransport(ctx, opts, conf, hostPort, len(opts.Hosts) > 1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return transport, conf, nil
+	}
+
 	var transport thrift.TTransport
 	var conn net.Conn
 
-	// We take over dialing from Thrift for two reasons: use context, and pass the connection to checkedTransport.
-	if opts.UseTLS {
+	dial, err := opts.dialer()
+	if err != nil {
+		return nil, nil, err
+	}
 
+	if opts.UseTLS {
 		conf.TLSConfig, err = getTLSConfig(opts)
 		if err != nil {
 			return nil, nil, err
 		}
+	}
 
-		dialer := tls.Dialer{
-			NetDialer: &net.Dialer{
-				Timeout: conf.GetConnectTimeout(),
-			},
-			Config: conf.TLSConfig,
-		}
+	dialCtx := ctx
+	if connectTimeout := conf.GetConnectTimeout(); connectTimeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(ctx, connectTimeout)
+		defer cancel()
+	}
+
+	// We take over dialing from Thrift for three reasons: use context, support custom dialers,
+	// and pass the connection to checkedTransport.
+	conn, err = dial(dialCtx, "tcp", hostPort)
+	if err != nil {
+		return nil, nil, wrapConnectErr(ctx, err, "")
+	}
 
-		conn, err = dialer.DialContext(ctx, "tcp", hostPort)
+	if opts.UseTLS {
+		tlsConn := tls.Client(conn, withServerName(conf.TLSConfig, addr.host))
+		err = tlsConn.HandshakeContext(dialCtx)
 		if err != nil {
+			_ = conn.Close()
 			var addInfo string
 			if opts.systemCAStoreSelected() {
 				addInfo = " (using system root CAs)"
 			}
 			return nil, nil, wrapConnectErr(ctx, err, addInfo)
 		}
-		transport = thrift.NewTSSLSocketFromConnConf(conn, conf)
-	} else {
-		dialer := net.Dialer{
-			Timeout: conf.GetConnectTimeout(),
-		}
-		conn, err = dialer.DialContext(ctx, "tcp", hostPort)
-		if err != nil {
-			return nil, nil, wrapConnectErr(ctx, err, "")
-		}
-		transport = thrift.NewTSSLSocketFromConnConf(conn, conf)
+		conn = tlsConn
 	}
+	transport = thrift.NewTSSLSocketFromConnConf(conn, conf)
 
 	transport = checkedTransport{
-		conn:       conn, // type guaranteed by DialContext doc
+		conn:       conn,
 		TTransport: transport,
 	}
 
-	if opts.UseLDAP {
-
-		if opts.Username == "" {
-			return nil, nil, fmt.Errorf("%w: provide username for LDAP auth", ErrBadDSN)
-		}
-
-		// Empty password will be used if not provided.
-
+	if mech := opts.saslMechanism(); mech != "" {
 		transport, err = sasl.NewTSaslTransport(transport, &sasl.Options{
-			Host:     opts.Host,
-			Username: opts.Username,
-			Password: opts.Password,
+			Mechanism: mech,
+			Service:   opts.KerberosService,
+			Host:      addr.host,
+			Principal: opts.KerberosPrincipal,
+			Username:  opts.Username,
+			Password:  opts.Password,
 		})
 
 		if err != nil {
-			// This never happens in the current version of thrift.
-			// NewTSaslTransport always returns nil error
-			return nil, nil, err
+			// The mechanism is not registered
+			_ = conn.Close()
+			return nil, nil, fmt.Errorf("%w: %w", ErrOpenFailed, err)
 		}
 
 		err = transport.Open()
 		if err != nil {
+			_ = conn.Close()
 			return nil, nil, fmt.Errorf("%w: authentication failed: %w", ErrOpenFailed, err)
 		}
 	} else {
@@ -365,8 +669,13 @@ func openTransport(ctx context.Context, opts *Options) (thrift.TTransport, *thri
 }
 
 func getTLSConfig(opts *Options) (*tls.Config, error) {
+	if opts.TLSConfig != nil {
+		return opts.TLSConfig, nil
+	}
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: opts.TLSInsecureSkipVerify,
+		ServerName:         opts.TLSServerName,
+		MinVersion:         opts.TLSMinVersion,
 	}
 	if certPath := opts.CACertPath; !opts.TLSInsecureSkipVerify && certPath != "" {
 		caCertPool, err := readCert(certPath)
@@ -375,8 +684,30 @@ func getTLSConfig(opts *Options) (*tls.Config, error) {
 		}
 		tlsConfig.RootCAs = caCertPool
 	}
+	if opts.ClientCertPath != "" || opts.ClientKeyPath != "" {
+		reloader := opts.clientCert
+		if reloader == nil {
+			var err error
+			reloader, err = newClientCertReloader(opts.ClientCertPath, opts.ClientKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to read client certificate: %w", ErrBadDSN, err)
+			}
+		}
+		tlsConfig.GetClientCertificate = reloader.getClientCertificate
+	}
 	return tlsConfig, nil
 }
+
+// withServerName returns a config with ServerName set to host, if not set already, like tls.Dialer does.
+func withServerName(config *tls.Config, host string) *tls.Config {
+	if config.ServerName != "" {
+		return config
+	}
+	config = config.Clone()
+	config.ServerName = host
+	return config
+}
+
 func wrapConnectErr(ctx context.Context, err error, addInfo string) error {
 	// Add information so the user can tell if "context deadline exceeded" means that
 	// the ConnectTimeout was exceeded or the deadline was from the given context.
@@ -403,7 +734,7 @@ func readCert(certPath string) (*x509.CertPool, error) {
 }
 
 func connectThrift(ctx context.Context, opts *Options) (thrift.TTransport, thrift.TClient, error) {
-	transport, conf, err := openTransport(ctx, opts)
+	transport, conf, err := openFirstTransport(ctx, opts)
 
 	if err != nil {
 		return nil, nil, err
@@ -413,3 +744,32 @@ func connectThrift(ctx context.Context, opts *Options) (thrift.TTransport, thrif
 	tclient := thrift.NewTStandardClient(protocol, protocol)
 	return transport, tclient, nil
 }
+
+// openFirstTransport opens a transport to the first available address among the configured hosts
+func openFirstTransport(ctx context.Context, opts *Options) (thrift.TTransport, *thrift.TConfiguration, error) {
+	addrs := opts.addresses()
+	if len(addrs) == 1 {
+		return openTransport(ctx, opts, addrs[0])
+	}
+
+	var errs []error
+	for _, addr := range addrs {
+		transport, conf, err := openTransport(ctx, opts, addr)
+		if err == nil {
+			quarantine.remove(addr.String())
+			return transport, conf, nil
+		}
+		if isHostIndependentErr(err) {
+			return nil, nil, err
+		}
+		errs = append(errs, fmt.Errorf("host %s: %w", addr, err))
+		if ctx.Err() != nil {
+			// the host may be fine - the caller gave up
+			break
+		}
+		if isHostFailure(err) {
+			quarantine.add(addr.String(), opts.HostQuarantine)
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: tried %d of %d hosts: %w", ErrOpenFailed, len(errs), len(addrs), errors.Join(errs...))
+}
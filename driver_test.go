@@ -2,15 +2,20 @@ package impala
 
 import (
 	"context"
+	"crypto/tls"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"net"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/jinzhu/copier"
 	"github.com/murfffi/gorich/fi"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,8 +71,18 @@ func TestParseURI(t *testing.T) {
 			Options{Host: "localhost", UseTLS: true, TLSInsecureSkipVerify: true},
 		},
 		{
-			"impala://localhost?batch-size=2048&buffer-size=2048",
-			Options{Host: "localhost", BatchSize: 2048, BufferSize: 2048},
+			"impala://localhost?tls=true&client-cert=/etc/client.crt&client-key=/etc/client.key&tls-server-name=impala&tls-min-version=1.3",
+			Options{Host: "localhost", UseTLS: true, ClientCertPath: "/etc/client.crt", ClientKeyPath: "/etc/client.key",
+				TLSServerName: "impala", TLSMinVersion: tls.VersionTLS13},
+		},
+		{
+			"impala://localhost?batch-size=2048&buffer-size=2048&prefetch=4",
+			Options{Host: "localhost", BatchSize: 2048, BufferSize: 2048, Prefetch: 4},
+		},
+		{
+			"impala://localhost?batch-target-bytes=1048576&batch-size-min=100&batch-size-max=10000&batch-max-fetch-time=2s",
+			Options{Host: "localhost", BatchTargetBytes: 1 << 20, BatchSizeMin: 100, BatchSizeMax: 10000,
+				BatchMaxFetchTime: 2 * time.Second},
 		},
 		{
 			"impala://localhost?mem-limit=1g",
@@ -81,6 +96,59 @@ func TestParseURI(t *testing.T) {
 			"impala://localhost?connect-timeout=1",
 			Options{Host: "localhost", ConnectTimeout: 1 * time.Millisecond},
 		},
+		{
+			"impala://localhost?auth=kerberos&krb5-service=impalad&krb5-principal=fry@EXAMPLE.COM",
+			Options{Host: "localhost", UseKerberos: true, KerberosService: "impalad", KerberosPrincipal: "fry@EXAMPLE.COM"},
+		},
+		{
+			"impala://h1:21000,h2,h3?host-selection=round-robin&host-quarantine=1m",
+			Options{Hosts: []string{"h1:21000", "h2", "h3"}, HostSelection: HostSelectionRoundRobin, HostQuarantine: time.Minute},
+		},
+		{
+			"impala://localhost?set.REQUEST_POOL=etl&query-option.explain_level=2&strict-session-config=true",
+			Options{Host: "localhost", SessionConfig: map[string]string{"REQUEST_POOL": "etl", "explain_level": "2"},
+				StrictSessionConfig: true},
+		},
+		{
+			"impala+http://localhost",
+			Options{Host: "localhost", Port: "28000", UseHTTP: true},
+		},
+		{
+			"impala://localhost?impersonate=alice",
+			Options{Host: "localhost", ImpersonateUser: "alice"},
+		},
+		{
+			"impala://localhost?profile-on-error=true",
+			Options{Host: "localhost", ProfileOnError: true},
+		},
+		{
+			"impala://localhost?fail-on-row-errors=true",
+			Options{Host: "localhost", FailOnRowErrors: true},
+		},
+		{
+			"impala://localhost?multi-statements=true",
+			Options{Host: "localhost", MultiStatements: true},
+		},
+		{
+			"impala://localhost?exec-mode=ASYNC",
+			Options{Host: "localhost", ExecMode: ExecModeAsync},
+		},
+		{
+			"impala://localhost/sales?batch-size=10",
+			Options{Host: "localhost", Database: "sales", BatchSize: 10},
+		},
+		{
+			"impala+https://localhost:443?http-path=gateway/impala",
+			Options{Host: "localhost", Port: "443", UseHTTP: true, UseTLS: true, HTTPPath: "gateway/impala"},
+		},
+		{
+			"impala://localhost?transport=http",
+			Options{Host: "localhost", Port: "28000", UseHTTP: true},
+		},
+		{
+			"https://localhost",
+			Options{Host: "localhost", Port: "28000", UseHTTP: true, UseTLS: true},
+		},
 	}
 
 	for _, tt := range tests {
@@ -107,13 +175,48 @@ func TestParseURI_Negative(t *testing.T) {
 		require.ErrorIs(t, err, ErrBadDSN)
 		require.ErrorContains(t, err, "parse")
 	})
-	for _, key := range []string{"batch-size", "buffer-size", "query-timeout", "tls", "socket-timeout", "connect-timeout"} {
+	for _, key := range []string{"batch-size", "buffer-size", "prefetch", "batch-target-bytes", "batch-max-fetch-time",
+		"query-timeout", "tls", "socket-timeout", "connect-timeout"} {
 		t.Run("invalid "+key, func(t *testing.T) {
 			_, err := drv.Open(fmt.Sprintf("impala://localhost?%s=aa", key))
 			require.ErrorIs(t, err, ErrBadDSN)
 			require.ErrorContains(t, err, "invalid "+key)
 		})
 	}
+	t.Run("invalid tls-min-version", func(t *testing.T) {
+		_, err := drv.Open("impala://localhost?tls=true&tls-min-version=2")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "invalid tls-min-version")
+	})
+	t.Run("invalid host-selection", func(t *testing.T) {
+		_, err := drv.Open("impala://h1,h2?host-selection=best")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "invalid host-selection")
+	})
+	t.Run("invalid exec-mode", func(t *testing.T) {
+		_, err := drv.Open("impala://localhost?exec-mode=later")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "invalid exec-mode")
+	})
+	t.Run("batch size bounds", func(t *testing.T) {
+		_, err := drv.Open("impala://localhost?batch-target-bytes=1024&batch-size-min=100&batch-size-max=10")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "batch-size-min 100 is greater than batch-size-max 10")
+	})
+	t.Run("nested path", func(t *testing.T) {
+		_, err := drv.Open("impala://localhost/sales/2024")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "single segment")
+	})
+	t.Run("empty host", func(t *testing.T) {
+		_, err := drv.Open("impala://h1,,h2")
+		require.ErrorIs(t, err, ErrBadDSN)
+	})
+	t.Run("invalid transport", func(t *testing.T) {
+		_, err := drv.Open("impala://localhost?transport=grpc")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "invalid transport")
+	})
 	t.Run("invalid ca-cert", func(t *testing.T) {
 		_, err := drv.Open("impala://localhost?tls=true&ca-cert=aa")
 		require.ErrorIs(t, err, ErrBadDSN)
@@ -121,6 +224,26 @@ func TestParseURI_Negative(t *testing.T) {
 	})
 }
 
+func TestConnect_SASL(t *testing.T) {
+	port := createUnresponsiveSocket(t)
+	t.Run("gssapi not registered", func(t *testing.T) {
+		opts := DefaultOptions
+		opts.Host = "localhost"
+		opts.Port = strconv.Itoa(port)
+		opts.UseKerberos = true
+		_, err := connect(context.Background(), &opts, nil)
+		require.ErrorIs(t, err, ErrOpenFailed)
+		require.ErrorContains(t, err, "GSSAPI is not registered")
+	})
+	t.Run("kerberos over http", func(t *testing.T) {
+		opts := DefaultOptions
+		opts.UseKerberos = true
+		opts.UseHTTP = true
+		_, err := connect(context.Background(), &opts, nil)
+		require.ErrorIs(t, err, ErrBadDSN)
+	})
+}
+
 func TestDriver_Integration(t *testing.T) {
 	fi.SkipLongTest(t)
 
@@ -132,7 +255,7 @@ func TestDriver_Integration(t *testing.T) {
 				Port:          strconv.Itoa(port),
 				SocketTimeout: 100 * time.Millisecond,
 			}
-			conn, err := connect(context.Background(), opts)
+			conn, err := connect(context.Background(), opts, nil)
 			require.NoError(t, err)
 			_, err = conn.OpenSession(context.Background()) // thrift ignores context in most cases
 			require.ErrorIs(t, err, driver.ErrBadConn)
@@ -146,7 +269,7 @@ func TestDriver_Integration(t *testing.T) {
 			}
 			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 			defer cancel()
-			_, err := connect(ctx, opts)
+			_, err := connect(ctx, opts, nil)
 			require.ErrorIs(t, err, ErrOpenFailed)
 			require.ErrorIs(t, err, context.DeadlineExceeded)
 		})
@@ -162,7 +285,7 @@ func TestDriver_Integration(t *testing.T) {
 				UseTLS:         true,
 				ConnectTimeout: 100 * time.Millisecond,
 			}
-			_, err := connect(context.Background(), opts)
+			_, err := connect(context.Background(), opts, nil)
 			t.Log(err)
 			require.ErrorIs(t, err, ErrOpenFailed)
 			require.ErrorIs(t, err, context.DeadlineExceeded)
@@ -186,3 +309,81 @@ func createUnresponsiveSocket(t *testing.T) int {
 	}()
 	return listener.Addr().(*net.TCPAddr).Port
 }
+
+func TestImpersonation(t *testing.T) {
+	fake := &fakeHS2{}
+	srv := httptest.NewServer(fake.httpHandler())
+	defer srv.Close()
+
+	opts := httpTestOptions(t, srv.URL)
+	opts.ImpersonateUser = "alice"
+	opts.ReuseSession = true
+	db := sql.OpenDB(NewConnector(opts))
+	defer fi.NoErrorF(db.Close, t)
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	require.NoError(t, db.PingContext(ctx))
+	require.NoError(t, db.PingContext(ctx))                               // session reused
+	require.NoError(t, db.PingContext(WithImpersonateUser(ctx, "bob")))   // same connection, new session
+	require.NoError(t, db.PingContext(WithImpersonateUser(ctx, "")))      // no impersonation
+	require.NoError(t, db.PingContext(WithImpersonateUser(ctx, "alice"))) // same as the default
+	require.Equal(t, []string{"alice", "bob", "", "alice"}, fake.getDoAsUsers())
+	require.Equal(t, 3, lo.Count(fake.getCalls(), "CloseSession"))
+}
+
+func TestQueryOptions(t *testing.T) {
+	fake := &fakeHS2{}
+	srv := httptest.NewServer(fake.httpHandler())
+	defer srv.Close()
+
+	db := sql.OpenDB(NewConnector(httpTestOptions(t, srv.URL)))
+	defer fi.NoErrorF(db.Close, t)
+
+	ctx := context.Background()
+	options := map[string]string{"MT_DOP": "4"}
+	optsCtx := WithQueryOptions(ctx, options)
+	options["MT_DOP"] = "8" // the context keeps a copy
+	_, err := db.ExecContext(optsCtx, "INSERT INTO t SELECT * FROM s")
+	require.NoError(t, err)
+	rows, err := db.QueryContext(WithQueryOptions(optsCtx, map[string]string{"REQUEST_POOL": "etl"}), "SELECT 1")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	_, err = db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{{"MT_DOP": "4"}, {"REQUEST_POOL": "etl"}, nil}, fake.getOverlays())
+}
+
+func TestQueryArgs(t *testing.T) {
+	fake := &fakeHS2{}
+	srv := httptest.NewServer(fake.httpHandler())
+	defer srv.Close()
+
+	db := sql.OpenDB(NewConnector(httpTestOptions(t, srv.URL)))
+	defer fi.NoErrorF(db.Close, t)
+
+	ctx := context.Background()
+	ts := time.Date(2024, time.March, 1, 13, 14, 15, 0, time.UTC)
+	_, err := db.ExecContext(ctx, "INSERT INTO t VALUES (?, ?, ?, ?, ?, ?)",
+		"it's", -1, 0.5, ts, DateOf(ts), sql.NullString{})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`INSERT INTO t VALUES ('it\'s', (-1), CAST(0.5 AS DOUBLE), CAST('2024-03-01 13:14:15' AS TIMESTAMP), ` +
+			`CAST('2024-03-01' AS DATE), NULL)`,
+	}, fake.getStatements())
+
+	_, err = db.ExecContext(ctx, "SELECT ?", math.NaN())
+	require.ErrorContains(t, err, "argument @p1")
+	_, err = db.ExecContext(ctx, "SELECT ? -- ?", 1, 2)
+	require.ErrorContains(t, err, "argument @p2 is not used")
+	stmt, err := db.PrepareContext(ctx, "SELECT ?, '?' /* ? */")
+	require.NoError(t, err)
+	defer fi.NoErrorF(stmt.Close, t)
+	_, err = stmt.ExecContext(ctx)
+	require.ErrorContains(t, err, "expected 1 arguments, got 0")
+	require.Len(t, fake.getStatements(), 1)
+
+	_, err = stmt.ExecContext(ctx, "?")
+	require.NoError(t, err)
+	require.Equal(t, "SELECT '?', '?' /* ? */", fake.getStatements()[1])
+}
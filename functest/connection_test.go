@@ -221,6 +221,7 @@ func runSuite(t *testing.T, dsn string) {
 
 func runCasesWithDsn(t *testing.T, db *sql.DB, dsn string) {
 	testSet(t, db, dsn)
+	testDatabase(t, dsn)
 
 	t.Run("reused session expired", func(t *testing.T) {
 		reuseSessionDsn := fi.NoError(url.Parse(dsn)).Require(t)
@@ -248,6 +249,28 @@ func runCasesWithDsn(t *testing.T, db *sql.DB, dsn string) {
 	})
 }
 
+func testDatabase(t *testing.T, dsn string) {
+	withDatabase := func(db string) *sql.DB {
+		dbURL := fi.NoError(url.Parse(dsn)).Require(t)
+		dbURL.Path = "/" + db
+		dbWithDatabase := fi.NoError(sql.Open("impala", dbURL.String())).Require(t)
+		t.Cleanup(func() { _ = dbWithDatabase.Close() })
+		return dbWithDatabase
+	}
+
+	t.Run("database in DSN", func(t *testing.T) {
+		var current string
+		require.NoError(t, withDatabase("_impala_builtins").QueryRow("SELECT current_database()").Scan(&current))
+		require.Equal(t, "_impala_builtins", current)
+	})
+
+	t.Run("missing database in DSN", func(t *testing.T) {
+		err := withDatabase("no_such_database").Ping()
+		require.ErrorIs(t, err, impala.ErrOpenFailed)
+		require.ErrorContains(t, err, "no_such_database")
+	})
+}
+
 func runHappyCases(t *testing.T, db *sql.DB) {
 	t.Run("Pinger", func(t *testing.T) {
 		testPinger(t, db)
@@ -618,7 +641,7 @@ func testInsert(t *testing.T, conn *sql.DB) {
 	var err error
 	_, err = conn.Exec("DROP TABLE IF EXISTS test")
 	require.NoError(t, err)
-	_, err = conn.Exec("CREATE TABLE test(a varchar)")
+	_, err = conn.Exec("CREATE TABLE test(a timestamp)")
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		_, err = conn.Exec("DROP TABLE IF EXISTS test")
@@ -639,15 +662,15 @@ func testInsert(t *testing.T, conn *sql.DB) {
 		require.NoError(t, err)
 		defer fi.NoErrorF(selectRes.Close, t)
 		require.True(t, selectRes.Next())
-		var val string
+		var val time.Time
 		require.NoError(t, selectRes.Scan(&val))
-		require.Equal(t, now.Format(hive.TimestampFormat), val)
+		require.Equal(t, now.Format(hive.TimestampFormat), val.Format(hive.TimestampFormat))
 		require.NoError(t, st.Close()) // close is no-op anyway
 	})
 
 	t.Run("cancel DML from Query", func(t *testing.T) {
 		startTime := time.Now()
-		dmlRes, err := conn.Query("INSERT INTO test (a) VALUES (cast(SLEEP(10000) as string))")
+		dmlRes, err := conn.Query("INSERT INTO test (a) VALUES (cast(cast(SLEEP(10000) as string) as timestamp))")
 		require.NoError(t, err)
 		err = dmlRes.Close()
 		require.NoError(t, err)
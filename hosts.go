@@ -0,0 +1,165 @@
+package impala
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HostSelection is the order in which the driver tries the hosts in Options.Hosts
+type HostSelection string
+
+// Supported HostSelection values
+const (
+	// HostSelectionOrdered tries the hosts in the listed order. This is the default.
+	HostSelectionOrdered HostSelection = "ordered"
+	// HostSelectionRandom tries the hosts in random order
+	HostSelectionRandom HostSelection = "random"
+	// HostSelectionRoundRobin starts each connection from the host after the one, the previous connection started with.
+	HostSelectionRoundRobin HostSelection = "round-robin"
+)
+
+func parseHostSelection(val string) (HostSelection, error) {
+	switch sel := HostSelection(strings.ToLower(val)); sel {
+	case HostSelectionOrdered, HostSelectionRandom, HostSelectionRoundRobin:
+		return sel, nil
+	default:
+		return "", fmt.Errorf("invalid host-selection value: %s", val)
+	}
+}
+
+// address is a host and port pair
+type address struct {
+	host string
+	port string
+}
+
+func (a address) String() string {
+	return net.JoinHostPort(a.host, a.port)
+}
+
+// addresses returns the addresses to try when connecting, in the order they should be tried.
+func (o *Options) addresses() []address {
+	if len(o.Hosts) == 0 {
+		return []address{{host: o.Host, port: o.Port}}
+	}
+	addrs := make([]address, 0, len(o.Hosts))
+	for _, entry := range o.Hosts {
+		addrs = append(addrs, parseAddress(entry, o.Port))
+	}
+
+	switch o.HostSelection {
+	case HostSelectionRandom:
+		rand.Shuffle(len(addrs), func(i, j int) {
+			addrs[i], addrs[j] = addrs[j], addrs[i]
+		})
+	case HostSelectionRoundRobin:
+		counter, _ := roundRobinCounters.LoadOrStore(strings.Join(o.Hosts, ","), new(atomic.Uint64))
+		start := int(counter.(*atomic.Uint64).Add(1)-1) % len(addrs)
+		addrs = append(addrs[start:], addrs[:start]...)
+	}
+
+	// Quarantined hosts are tried last instead of skipped, so a connection can be made
+	// if they recovered while all other hosts failed.
+	now := time.Now()
+	slices.SortStableFunc(addrs, func(a, b address) int {
+		aq, bq := quarantine.contains(a.String(), now), quarantine.contains(b.String(), now)
+		switch {
+		case aq == bq:
+			return 0
+		case aq:
+			return 1
+		default:
+			return -1
+		}
+	})
+	return addrs
+}
+
+// parseAddress parses a host list entry in the form host, host:port, or [ipv6]:port
+func parseAddress(entry string, defaultPort string) address {
+	host, port, err := net.SplitHostPort(entry)
+	if err != nil {
+		// no port
+		return address{host: strings.Trim(entry, "[]"), port: defaultPort}
+	}
+	return address{host: host, port: port}
+}
+
+// extractHostList replaces a comma-separated host list in the URI authority with its first entry,
+// so the URI can be parsed by net/url, and returns the list. The list is nil if the URI has a single host.
+func extractHostList(uri string) (string, []string) {
+	schemeEnd := strings.Index(uri, "://")
+	if schemeEnd < 0 {
+		return uri, nil
+	}
+	start := schemeEnd + len("://")
+	end := len(uri)
+	if i := strings.IndexAny(uri[start:], "/?#"); i >= 0 {
+		end = start + i
+	}
+	hostStart := start + strings.LastIndex(uri[start:end], "@") + 1
+	hosts := uri[hostStart:end]
+	if !strings.Contains(hosts, ",") {
+		return uri, nil
+	}
+	list := strings.Split(hosts, ",")
+	return uri[:hostStart] + list[0] + uri[end:], list
+}
+
+// roundRobinCounters contains an *atomic.Uint64 for each host list, used with HostSelectionRoundRobin.
+// The state is global so that it is shared by connections opened through sql.Open and through connectors.
+var roundRobinCounters sync.Map
+
+var quarantine = &hostQuarantine{until: make(map[string]time.Time)}
+
+// hostQuarantine tracks hosts that recently failed. It is global because a failed host
+// is failed for all connections.
+type hostQuarantine struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+func (q *hostQuarantine) add(addr string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.until[addr] = time.Now().Add(d)
+}
+
+func (q *hostQuarantine) remove(addr string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.until, addr)
+}
+
+func (q *hostQuarantine) contains(addr string, now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	until, ok := q.until[addr]
+	if ok && now.After(until) {
+		delete(q.until, addr)
+		return false
+	}
+	return ok
+}
+
+// isHostIndependentErr returns true for errors that would occur regardless of the host, so trying another is pointless
+func isHostIndependentErr(err error) bool {
+	return errors.Is(err, ErrBadDSN)
+}
+
+// isHostFailure returns true for errors that show that the host is unreachable or broken e.g. dial and transport
+// errors, so it should be quarantined. Rejected credentials are not a host failure: all hosts would reject them.
+func isHostFailure(err error) bool {
+	var authErr *AuthError
+	return !errors.As(err, &authErr)
+}
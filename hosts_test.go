@@ -0,0 +1,164 @@
+package impala
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractHostList(t *testing.T) {
+	tests := []struct {
+		in    string
+		out   string
+		hosts []string
+	}{
+		{"impala://localhost", "impala://localhost", nil},
+		{"impala://h1:21050,h2:21050,h3", "impala://h1:21050", []string{"h1:21050", "h2:21050", "h3"}},
+		{"impala://u:p@h1,h2/db?x=a,b", "impala://u:p@h1/db?x=a,b", []string{"h1", "h2"}},
+		{"impala://h1?hosts=a,b", "impala://h1?hosts=a,b", nil},
+		{"impala://[::1]:21050,[::2]", "impala://[::1]:21050", []string{"[::1]:21050", "[::2]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			out, hosts := extractHostList(tt.in)
+			require.Equal(t, tt.out, out)
+			require.Equal(t, tt.hosts, hosts)
+		})
+	}
+}
+
+func TestOptions_Addresses(t *testing.T) {
+	t.Run("default port", func(t *testing.T) {
+		opts := &Options{Hosts: []string{"h1:1", "h2", "[::1]"}, Port: "2"}
+		require.Equal(t, []address{{"h1", "1"}, {"h2", "2"}, {"::1", "2"}}, opts.addresses())
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		opts := &Options{Hosts: []string{"rr1", "rr2", "rr3"}, Port: "1", HostSelection: HostSelectionRoundRobin}
+		// the counters are global - start from the first host regardless of earlier runs
+		roundRobinCounters.Delete("rr1,rr2,rr3")
+		defer roundRobinCounters.Delete("rr1,rr2,rr3")
+		var firsts []string
+		for range 4 {
+			firsts = append(firsts, opts.addresses()[0].host)
+		}
+		require.Equal(t, []string{"rr1", "rr2", "rr3", "rr1"}, firsts)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		opts := &Options{Hosts: []string{"r1", "r2", "r3"}, Port: "1", HostSelection: HostSelectionRandom}
+		require.ElementsMatch(t, []address{{"r1", "1"}, {"r2", "1"}, {"r3", "1"}}, opts.addresses())
+	})
+
+	t.Run("quarantined last", func(t *testing.T) {
+		opts := &Options{Hosts: []string{"q1", "q2"}, Port: "1"}
+		quarantine.add("q1:1", DefaultOptions.HostQuarantine)
+		defer quarantine.remove("q1:1")
+		require.Equal(t, []address{{"q2", "1"}, {"q1", "1"}}, opts.addresses())
+	})
+}
+
+func TestConnect_Failover(t *testing.T) {
+	closedPort := createClosedPort(t)
+	livePort := createUnresponsiveSocket(t)
+	closedAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(closedPort))
+	liveAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(livePort))
+
+	t.Run("next host", func(t *testing.T) {
+		opts := DefaultOptions
+		opts.Hosts = []string{closedAddr, liveAddr}
+		conn, err := connect(context.Background(), &opts, nil)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		require.True(t, quarantine.contains(closedAddr, time.Now()))
+		quarantine.remove(closedAddr)
+	})
+
+	t.Run("next host with HTTP", func(t *testing.T) {
+		fake := &fakeHS2{}
+		srv := httptest.NewServer(fake.httpHandler())
+		defer srv.Close()
+		opts := httpTestOptions(t, srv.URL)
+		opts.Hosts = []string{closedAddr, net.JoinHostPort(opts.Host, opts.Port)}
+		conn, err := connect(context.Background(), opts, nil)
+		require.NoError(t, err)
+		require.NoError(t, conn.Ping(context.Background()))
+		require.NoError(t, conn.Close())
+		require.True(t, quarantine.contains(closedAddr, time.Now()))
+		quarantine.remove(closedAddr)
+	})
+
+	t.Run("all failed", func(t *testing.T) {
+		otherClosedAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(createClosedPort(t)))
+		opts := DefaultOptions
+		opts.Hosts = []string{closedAddr, otherClosedAddr}
+		opts.HostQuarantine = 0
+		_, err := connect(context.Background(), &opts, nil)
+		require.ErrorIs(t, err, ErrOpenFailed)
+		require.ErrorContains(t, err, closedAddr)
+		require.ErrorContains(t, err, otherClosedAddr)
+		require.False(t, quarantine.contains(closedAddr, time.Now()))
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		rejectingAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(createClosingSocket(t)))
+		opts := DefaultOptions
+		opts.Hosts = []string{rejectingAddr, closedAddr}
+		opts.UseLDAP = true
+		opts.Username = "user"
+		_, err := connect(context.Background(), &opts, nil)
+		var authErr *AuthError
+		require.ErrorAs(t, err, &authErr)
+		require.False(t, quarantine.contains(rejectingAddr, time.Now()))
+		require.True(t, quarantine.contains(closedAddr, time.Now()))
+		quarantine.remove(closedAddr)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		otherClosedAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(createClosedPort(t)))
+		opts := DefaultOptions
+		opts.Hosts = []string{closedAddr, otherClosedAddr}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := connect(ctx, &opts, nil)
+		require.ErrorIs(t, err, context.Canceled)
+		require.False(t, quarantine.contains(closedAddr, time.Now()))
+		require.False(t, quarantine.contains(otherClosedAddr, time.Now()))
+	})
+}
+
+// createClosingSocket returns a port of a server that closes connections after the client sent its data,
+// like Impala does when SASL authentication fails
+func createClosingSocket(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			// read the handshake first, so the client gets EOF instead of a connection reset
+			_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+			_, _ = io.Copy(io.Discard, conn)
+			_ = conn.Close()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// createClosedPort returns a port that was just free so connecting to it most likely fails
+func createClosedPort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return port
+}
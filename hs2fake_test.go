@@ -0,0 +1,451 @@
+package impala
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/google/uuid"
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/generated/execstats"
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/sclgo/impala-go/internal/generated/metrics"
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
+)
+
+// fakeHS2 is a minimal in-process Impala HS2 server for tests that don't need Impala.
+// Methods that are not implemented panic, failing the RPC.
+type fakeHS2 struct {
+	impalaservice.ImpalaHiveServer2Service
+
+	// runningPolls is how many times GetOperationStatus reports RUNNING before FINISHED
+	runningPolls int
+	// fetchPending makes FetchResults report that rows are not available yet
+	fetchPending bool
+	// cancelFails makes CancelOperation return an error status
+	cancelFails bool
+	// queryFails makes GetOperationStatus report that queries failed
+	queryFails bool
+	// queryLog is returned by GetLog
+	queryLog string
+	// infoMessages are returned with the ExecuteStatement status and the status of batches
+	infoMessages []string
+	// dmlResult is returned when operations are closed
+	dmlResult *impalaservice.TDmlResult_
+	// failStatement, if not empty, makes ExecuteStatement fail for statements that contain it
+	failStatement string
+	// retryableFailures is how many operations fail with an Impala error that the driver can retry
+	retryableFailures int
+	// droppedExecutes is how many ExecuteStatement requests are dropped by closing the HTTP connection
+	droppedExecutes int
+	// batches, if not 0, is the number of batches of FetchResults, each with MaxRows rows numbered from 0.
+	// Otherwise, FetchResults returns the rows 1 and 2.
+	batches int
+	// fetchLatency delays each FetchResults response
+	fetchLatency time.Duration
+	// pendingFetches is how many FetchResults requests of each operation report that rows are not available yet
+	pendingFetches int
+
+	mu         sync.Mutex
+	calls      []string
+	doAsUsers  []string
+	overlays   []map[string]string
+	statements []string
+	opPolls    map[string]int
+	canceled   map[string]bool
+	failing    map[string]bool
+	fetched    map[string]int
+	fetchPolls map[string]int
+}
+
+func (f *fakeHS2) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeHS2) getCalls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeHS2) httpHandler() http.HandlerFunc {
+	pf := thrift.NewTBinaryProtocolFactoryConf(nil)
+	processor := impalaservice.NewImpalaHiveServer2ServiceProcessor(f)
+	processor.AddToProcessorMap("CloseOperation", closeImpalaOperationProcessor{f})
+	handler := thrift.NewThriftHandlerFunc(processor, pf, pf)
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if bytes.Contains(body, []byte("ExecuteStatement")) && f.takeDroppedExecute() {
+			f.record("ExecuteStatement dropped")
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+	}
+}
+
+func (f *fakeHS2) takeDroppedExecute() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.droppedExecutes == 0 {
+		return false
+	}
+	f.droppedExecutes--
+	return true
+}
+
+// isFailing tells if the operation is one of the retryableFailures
+func (f *fakeHS2) isFailing(handle *cli_service.TOperationHandle) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.failing[string(handle.OperationId.GUID)]
+}
+
+func retryableFailureStatus() *cli_service.TStatus {
+	return &cli_service.TStatus{
+		StatusCode:   cli_service.TStatusCode_ERROR_STATUS,
+		ErrorMessage: thrift.StringPtr("Cancelled due to unreachable impalad(s): executor-1:27000"),
+	}
+}
+
+// closeImpalaOperationProcessor serves CloseImpalaOperation, which the client sends with the CloseOperation
+// method name. The generated processor would serve the HS2 CloseOperation, without the DML result.
+type closeImpalaOperationProcessor struct {
+	handler *fakeHS2
+}
+
+func (p closeImpalaOperationProcessor) Process(ctx context.Context, seqID int32, iprot, oprot thrift.TProtocol) (bool, thrift.TException) {
+	var args impalaservice.ImpalaHiveServer2ServiceCloseImpalaOperationArgs
+	if err := args.Read(ctx, iprot); err != nil {
+		return false, thrift.WrapTException(err)
+	}
+	if err := iprot.ReadMessageEnd(ctx); err != nil {
+		return false, thrift.WrapTException(err)
+	}
+	resp, err := p.handler.CloseImpalaOperation(ctx, args.Req)
+	if err != nil {
+		return false, thrift.WrapTException(err)
+	}
+	result := impalaservice.ImpalaHiveServer2ServiceCloseImpalaOperationResult{Success: resp}
+	if err := oprot.WriteMessageBegin(ctx, "CloseOperation", thrift.REPLY, seqID); err != nil {
+		return false, thrift.WrapTException(err)
+	}
+	if err := result.Write(ctx, oprot); err != nil {
+		return false, thrift.WrapTException(err)
+	}
+	if err := oprot.WriteMessageEnd(ctx); err != nil {
+		return false, thrift.WrapTException(err)
+	}
+	return true, thrift.WrapTException(oprot.Flush(ctx))
+}
+
+func (f *fakeHS2) getDoAsUsers() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.doAsUsers...)
+}
+
+func (f *fakeHS2) getOverlays() []map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]map[string]string(nil), f.overlays...)
+}
+
+func (f *fakeHS2) getStatements() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+func (f *fakeHS2) OpenSession(_ context.Context, req *cli_service.TOpenSessionReq) (*cli_service.TOpenSessionResp, error) {
+	f.record("OpenSession")
+	f.mu.Lock()
+	f.doAsUsers = append(f.doAsUsers, req.Configuration["impala.doas.user"])
+	f.mu.Unlock()
+	return &cli_service.TOpenSessionResp{
+		Status:                successStatus(),
+		ServerProtocolVersion: cli_service.TProtocolVersion_HIVE_CLI_SERVICE_PROTOCOL_V7,
+		SessionHandle:         &cli_service.TSessionHandle{SessionId: newHandleID()},
+	}, nil
+}
+
+func (f *fakeHS2) CloseSession(context.Context, *cli_service.TCloseSessionReq) (*cli_service.TCloseSessionResp, error) {
+	f.record("CloseSession")
+	return &cli_service.TCloseSessionResp{Status: successStatus()}, nil
+}
+
+func (f *fakeHS2) GetInfo(context.Context, *cli_service.TGetInfoReq) (*cli_service.TGetInfoResp, error) {
+	f.record("GetInfo")
+	return &cli_service.TGetInfoResp{
+		Status:    successStatus(),
+		InfoValue: &cli_service.TGetInfoValue{StringValue: thrift.StringPtr("fake")},
+	}, nil
+}
+
+func successStatus() *cli_service.TStatus {
+	return &cli_service.TStatus{StatusCode: cli_service.TStatusCode_SUCCESS_STATUS}
+}
+
+func newHandleID() *cli_service.THandleIdentifier {
+	guid := uuid.New()
+	secret := uuid.New()
+	return &cli_service.THandleIdentifier{GUID: guid[:], Secret: secret[:]}
+}
+
+func (f *fakeHS2) ExecuteStatement(_ context.Context, req *cli_service.TExecuteStatementReq) (*cli_service.TExecuteStatementResp, error) {
+	f.record("ExecuteStatement")
+	f.mu.Lock()
+	f.overlays = append(f.overlays, req.ConfOverlay)
+	f.statements = append(f.statements, req.Statement)
+	f.mu.Unlock()
+	if f.failStatement != "" && strings.Contains(req.Statement, f.failStatement) {
+		return &cli_service.TExecuteStatementResp{Status: &cli_service.TStatus{
+			StatusCode:   cli_service.TStatusCode_ERROR_STATUS,
+			ErrorMessage: thrift.StringPtr("AnalysisException: Syntax error"),
+		}}, nil
+	}
+	status := successStatus()
+	if len(f.infoMessages) > 0 {
+		status.StatusCode = cli_service.TStatusCode_SUCCESS_WITH_INFO_STATUS
+		status.InfoMessages = f.infoMessages
+	}
+	id := newHandleID()
+	f.mu.Lock()
+	if f.retryableFailures > 0 {
+		f.retryableFailures--
+		if f.failing == nil {
+			f.failing = make(map[string]bool)
+		}
+		f.failing[string(id.GUID)] = true
+	}
+	f.mu.Unlock()
+	return &cli_service.TExecuteStatementResp{
+		Status: status,
+		OperationHandle: &cli_service.TOperationHandle{
+			OperationId:   id,
+			OperationType: cli_service.TOperationType_EXECUTE_STATEMENT,
+			HasResultSet:  hasResultSet(req.Statement),
+		},
+	}, nil
+}
+
+// hasResultSet tells if the fake returns a result set for stmt. Only SET and DDL/DML statements don't have one.
+func hasResultSet(stmt string) bool {
+	keyword, _, _ := strings.Cut(strings.ToUpper(strings.TrimSpace(stmt)), " ")
+	return !slices.Contains([]string{"SET", "INSERT", "UPSERT", "UPDATE", "DELETE", "CREATE", "DROP"}, keyword)
+}
+
+func (f *fakeHS2) GetOperationStatus(_ context.Context, req *cli_service.TGetOperationStatusReq) (*cli_service.TGetOperationStatusResp, error) {
+	f.record("GetOperationStatus")
+	if f.isFailing(req.OperationHandle) {
+		return &cli_service.TGetOperationStatusResp{Status: retryableFailureStatus()}, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := string(req.OperationHandle.OperationId.GUID)
+	state := cli_service.TOperationState_FINISHED_STATE
+	if f.canceled[id] {
+		state = cli_service.TOperationState_CANCELED_STATE
+	} else if f.queryFails {
+		state = cli_service.TOperationState_ERROR_STATE
+		return &cli_service.TGetOperationStatusResp{
+			Status:         successStatus(),
+			OperationState: &state,
+			ErrorMessage:   thrift.StringPtr("Memory limit exceeded"),
+		}, nil
+	} else if f.opPolls[id] < f.runningPolls {
+		state = cli_service.TOperationState_RUNNING_STATE
+	}
+	if f.opPolls == nil {
+		f.opPolls = make(map[string]int)
+	}
+	f.opPolls[id]++
+	return &cli_service.TGetOperationStatusResp{Status: successStatus(), OperationState: &state}, nil
+}
+
+func (f *fakeHS2) CancelOperation(_ context.Context, req *cli_service.TCancelOperationReq) (*cli_service.TCancelOperationResp, error) {
+	f.record("CancelOperation")
+	if f.cancelFails {
+		return &cli_service.TCancelOperationResp{Status: &cli_service.TStatus{
+			StatusCode:   cli_service.TStatusCode_ERROR_STATUS,
+			ErrorMessage: thrift.StringPtr("cancel failed"),
+		}}, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.canceled == nil {
+		f.canceled = make(map[string]bool)
+	}
+	f.canceled[string(req.OperationHandle.OperationId.GUID)] = true
+	return &cli_service.TCancelOperationResp{Status: successStatus()}, nil
+}
+
+// CloseImpalaOperation is recorded as CloseOperation, the method name on the wire
+func (f *fakeHS2) CloseImpalaOperation(context.Context, *impalaservice.TCloseImpalaOperationReq) (*impalaservice.TCloseImpalaOperationResp, error) {
+	f.record("CloseOperation")
+	return &impalaservice.TCloseImpalaOperationResp{Status: successStatus(), DmlResult_: f.dmlResult}, nil
+}
+
+func (f *fakeHS2) GetResultSetMetadata(context.Context, *cli_service.TGetResultSetMetadataReq) (*cli_service.TGetResultSetMetadataResp, error) {
+	f.record("GetResultSetMetadata")
+	return &cli_service.TGetResultSetMetadataResp{
+		Status: successStatus(),
+		Schema: &cli_service.TTableSchema{Columns: []*cli_service.TColumnDesc{{
+			ColumnName: "x",
+			TypeDesc: &cli_service.TTypeDesc{Types: []*cli_service.TTypeEntry{{
+				PrimitiveEntry: &cli_service.TPrimitiveTypeEntry{Type: cli_service.TTypeId_INT_TYPE},
+			}}},
+		}}},
+	}, nil
+}
+
+// FetchResults returns the INT values 1 and 2, or the batches, if set
+func (f *fakeHS2) FetchResults(_ context.Context, req *cli_service.TFetchResultsReq) (*cli_service.TFetchResultsResp, error) {
+	f.record("FetchResults")
+	time.Sleep(f.fetchLatency)
+	if f.isFailing(req.OperationHandle) {
+		return &cli_service.TFetchResultsResp{Status: retryableFailureStatus()}, nil
+	}
+	if f.fetchPending || f.pollFetch(req.OperationHandle) {
+		return &cli_service.TFetchResultsResp{
+			Status:      &cli_service.TStatus{StatusCode: cli_service.TStatusCode_STILL_EXECUTING_STATUS},
+			HasMoreRows: thrift.BoolPtr(true),
+		}, nil
+	}
+	if f.batches > 0 {
+		return f.nextBatch(req), nil
+	}
+	return &cli_service.TFetchResultsResp{
+		Status:      successStatus(),
+		HasMoreRows: thrift.BoolPtr(false),
+		Results: &cli_service.TRowSet{Columns: []*cli_service.TColumn{{
+			I32Val: &cli_service.TI32Column{Values: []int32{1, 2}, Nulls: []byte{0}},
+		}}},
+	}, nil
+}
+
+// pollFetch counts a FetchResults request of the operation and tells if it is one of the pendingFetches
+func (f *fakeHS2) pollFetch(h *cli_service.TOperationHandle) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fetchPolls == nil {
+		f.fetchPolls = make(map[string]int)
+	}
+	key := string(h.OperationId.GUID)
+	f.fetchPolls[key]++
+	return f.fetchPolls[key] <= f.pendingFetches
+}
+
+// nextBatch returns the next of the batches of the operation
+func (f *fakeHS2) nextBatch(req *cli_service.TFetchResultsReq) *cli_service.TFetchResultsResp {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fetched == nil {
+		f.fetched = make(map[string]int)
+	}
+	key := string(req.OperationHandle.OperationId.GUID)
+	n := f.fetched[key]
+	f.fetched[key]++
+	values := make([]int32, req.MaxRows)
+	for i := range values {
+		values[i] = int32(n*len(values) + i)
+	}
+	status := successStatus()
+	if len(f.infoMessages) > 0 {
+		status.StatusCode = cli_service.TStatusCode_SUCCESS_WITH_INFO_STATUS
+		status.InfoMessages = f.infoMessages
+	}
+	return &cli_service.TFetchResultsResp{
+		Status:      status,
+		HasMoreRows: thrift.BoolPtr(n+1 < f.batches),
+		Results: &cli_service.TRowSet{Columns: []*cli_service.TColumn{{
+			I32Val: &cli_service.TI32Column{Values: values, Nulls: make([]byte, (len(values)+7)/8)},
+		}}},
+	}
+}
+
+func (f *fakeHS2) GetLog(context.Context, *cli_service.TGetLogReq) (*cli_service.TGetLogResp, error) {
+	f.record("GetLog")
+	return &cli_service.TGetLogResp{Status: successStatus(), Log: f.queryLog}, nil
+}
+
+// GetRuntimeProfile returns a small profile with the query ID decoded from the operation GUID
+func (f *fakeHS2) GetRuntimeProfile(_ context.Context, req *impalaservice.TGetRuntimeProfileReq) (*impalaservice.TGetRuntimeProfileResp, error) {
+	f.record("GetRuntimeProfile")
+	guid := req.OperationHandle.OperationId.GUID
+	name := fmt.Sprintf("Query (id=%016x:%016x)", binary.LittleEndian.Uint64(guid[:8]), binary.LittleEndian.Uint64(guid[8:]))
+	resp := &impalaservice.TGetRuntimeProfileResp{Status: successStatus()}
+	switch req.Format {
+	case runtimeprofile.TRuntimeProfileFormat_STRING:
+		resp.Profile = thrift.StringPtr(name + ":\n  Summary:\n    Query State: FINISHED\n")
+	case runtimeprofile.TRuntimeProfileFormat_BASE64:
+		resp.Profile = thrift.StringPtr("eJzLSM3JyVcozy/KSQEAGgQEXQ==")
+	case runtimeprofile.TRuntimeProfileFormat_THRIFT:
+		resp.ThriftProfile = &runtimeprofile.TRuntimeProfileTree{Nodes: []*runtimeprofile.TRuntimeProfileNode{
+			{Name: name, NumChildren: 1, InfoStrings: map[string]string{}},
+			{
+				Name:                    "Summary",
+				Counters:                []*runtimeprofile.TCounter{{Name: "TotalTime", Unit: metrics.TUnit_TIME_NS, Value: 5}},
+				InfoStrings:             map[string]string{"Query State": "FINISHED"},
+				InfoStringsDisplayOrder: []string{"Query State"},
+			},
+		}}
+	}
+	return resp, nil
+}
+
+// GetExecSummary reports a query that is queued at the first status poll, then runs, scanning
+// one of runningPolls scan ranges per poll, until it finishes
+func (f *fakeHS2) GetExecSummary(_ context.Context, req *impalaservice.TGetExecSummaryReq) (*impalaservice.TGetExecSummaryResp, error) {
+	f.record("GetExecSummary")
+	f.mu.Lock()
+	polls := f.opPolls[string(req.OperationHandle.OperationId.GUID)]
+	f.mu.Unlock()
+	total := int64(f.runningPolls)
+	summary := &execstats.TExecSummary{
+		State: execstats.TExecState_RUNNING,
+		Progress: &execstats.TExecProgress{
+			TotalScanRanges:        thrift.Int64Ptr(total),
+			NumCompletedScanRanges: thrift.Int64Ptr(min(int64(max(polls-1, 0)), total)),
+		},
+		Nodes: []*execstats.TPlanNodeExecSummary{{
+			NodeID:         0,
+			FragmentIdx:    0,
+			Label:          "00:SCAN HDFS",
+			LabelDetail:    thrift.StringPtr("default.t"),
+			NumHosts:       thrift.Int32Ptr(2),
+			EstimatedStats: &execstats.TExecStats{Cardinality: thrift.Int64Ptr(100), MemoryUsed: thrift.Int64Ptr(1024)},
+			ExecStats: []*execstats.TExecStats{
+				{LatencyNs: thrift.Int64Ptr(5), CPUTimeNs: thrift.Int64Ptr(3), Cardinality: thrift.Int64Ptr(40), MemoryUsed: thrift.Int64Ptr(512)},
+				{LatencyNs: thrift.Int64Ptr(7), CPUTimeNs: thrift.Int64Ptr(4), Cardinality: thrift.Int64Ptr(50), MemoryUsed: thrift.Int64Ptr(256)},
+			},
+		}},
+	}
+	switch {
+	case polls > f.runningPolls:
+		summary.State = execstats.TExecState_FINISHED
+	case polls <= 1:
+		summary.State = execstats.TExecState_QUEUED
+		summary.IsQueued = thrift.BoolPtr(true)
+		summary.QueuedReason = thrift.StringPtr("queue is full")
+	}
+	return &impalaservice.TGetExecSummaryResp{Status: successStatus(), Summary: summary}, nil
+}
@@ -0,0 +1,185 @@
+package impala
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+)
+
+// httpAuthCookie is the cookie Impala returns after successful authentication over hs2-http.
+// Presenting it in later requests skips re-authentication.
+const httpAuthCookie = "impala.auth"
+
+// openHTTPTransport opens a transport to the hs2-http endpoint of the host. Unless probe is true,
+// the host is not contacted until the first request.
+func openHTTPTransport(ctx context.Context, opts *Options, conf *thrift.TConfiguration, hostPort string, probe bool) (thrift.TTransport, error) {
+	scheme := "http"
+	if opts.UseTLS {
+		scheme = "https"
+	}
+	endpoint := url.URL{
+		Scheme: scheme,
+		Host:   hostPort,
+		Path:   "/" + strings.TrimPrefix(opts.HTTPPath, "/"),
+	}
+
+	client, err := newHTTPClient(opts, conf)
+	if err != nil {
+		return nil, err
+	}
+	if probe {
+		err = probeHTTP(ctx, client.Transport.(*httpAuthTransport).base, endpoint.String(), conf.GetConnectTimeout())
+		if err != nil {
+			return nil, wrapConnectErr(ctx, err, "")
+		}
+	}
+
+	transport, err := thrift.NewTHttpClientWithOptions(endpoint.String(), thrift.THttpClientOptions{Client: client})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadDSN, err)
+	}
+	return transport, nil
+}
+
+// probeHTTP sends a HEAD request to the endpoint, without authentication, to check that the host is reachable.
+// Any response will do. The connection is kept by the transport for the requests that follow.
+func probeHTTP(ctx context.Context, transport http.RoundTripper, endpoint string, connectTimeout time.Duration) error {
+	if connectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, connectTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// newHTTPClient returns a client, dedicated to a single connection, so the auth. cookie is not shared.
+func newHTTPClient(opts *Options, conf *thrift.TConfiguration) (*http.Client, error) {
+	var client http.Client
+	if opts.HTTPClient != nil {
+		client = *opts.HTTPClient
+	} else {
+		dial, err := opts.dialer()
+		if err != nil {
+			return nil, err
+		}
+		connectTimeout := conf.GetConnectTimeout()
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				if connectTimeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, connectTimeout)
+					defer cancel()
+				}
+				return dial(ctx, network, addr)
+			},
+			TLSHandshakeTimeout: connectTimeout,
+		}
+		if opts.UseTLS {
+			tlsConfig, err := getTLSConfig(opts)
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		client.Transport = transport
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &httpAuthTransport{
+		base:         base,
+		useBasicAuth: opts.UseLDAP,
+		username:     opts.Username,
+		password:     opts.Password,
+	}
+	return &client, nil
+}
+
+// httpAuthTransport keeps the impala.auth cookie and sends it with subsequent requests.
+// Requests carry basic auth. credentials, if enabled, until the server returns the cookie
+// or if the server rejects the cookie e.g. because it expired.
+//
+// http.Client.Jar is not used because the client adds the cookies to the request headers
+// in place, and thrift.THttpClient reuses the same headers across requests.
+type httpAuthTransport struct {
+	base         http.RoundTripper
+	useBasicAuth bool
+	username     string
+	password     string
+
+	mu     sync.Mutex
+	cookie *http.Cookie
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *httpAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cookie := t.getCookie()
+	resp, err := t.roundTrip(req, cookie)
+	if err == nil && cookie != nil && t.useBasicAuth &&
+		resp.StatusCode == http.StatusUnauthorized && req.GetBody != nil {
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		t.setCookie(nil)
+
+		retry := req.Clone(req.Context())
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		resp, err = t.roundTrip(retry, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range resp.Cookies() {
+		if c.Name == httpAuthCookie {
+			t.setCookie(c)
+		}
+	}
+	return resp, nil
+}
+
+func (t *httpAuthTransport) roundTrip(req *http.Request, cookie *http.Cookie) (*http.Response, error) {
+	// RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	if cookie != nil {
+		req.AddCookie(cookie)
+	} else if t.useBasicAuth {
+		req.SetBasicAuth(t.username, t.password)
+	}
+	return t.base.RoundTrip(req)
+}
+
+func (t *httpAuthTransport) getCookie() *http.Cookie {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cookie
+}
+
+func (t *httpAuthTransport) setCookie(c *http.Cookie) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if c != nil && c.MaxAge < 0 {
+		c = nil // the server deleted the cookie
+	}
+	t.cookie = c
+}
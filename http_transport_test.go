@@ -0,0 +1,115 @@
+package impala
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPTransport(t *testing.T) {
+	t.Run("plain with basic auth and cookie", func(t *testing.T) {
+		fake := &fakeHS2{}
+		auth := &authRecorder{next: fake.httpHandler()}
+		srv := httptest.NewServer(auth)
+		defer srv.Close()
+
+		opts := httpTestOptions(t, srv.URL)
+		opts.UseLDAP = true
+		opts.Username = "fry"
+		opts.Password = "secret"
+
+		pingAndClose(t, opts)
+
+		require.Equal(t, []string{"OpenSession", "GetInfo", "CloseSession"}, fake.getCalls())
+		require.Equal(t, []string{"/cliservice"}, auth.distinctPaths())
+		// only the first request authenticates with credentials. The others use the cookie.
+		require.Equal(t, []bool{true, false, false}, auth.basicAuth)
+		require.Equal(t, []bool{false, true, true}, auth.cookie)
+	})
+
+	t.Run("tls without auth", func(t *testing.T) {
+		fake := &fakeHS2{}
+		auth := &authRecorder{next: fake.httpHandler()}
+		srv := httptest.NewTLSServer(auth)
+		defer srv.Close()
+
+		opts := httpTestOptions(t, srv.URL)
+		opts.UseTLS = true
+		opts.TLSInsecureSkipVerify = true
+		opts.HTTPPath = "custom"
+
+		pingAndClose(t, opts)
+		require.Equal(t, []string{"/custom"}, auth.distinctPaths())
+		require.Equal(t, []bool{false, false, false}, auth.basicAuth)
+	})
+
+	t.Run("custom client", func(t *testing.T) {
+		fake := &fakeHS2{}
+		srv := httptest.NewTLSServer(fake.httpHandler())
+		defer srv.Close()
+
+		opts := httpTestOptions(t, srv.URL)
+		opts.UseTLS = true
+		opts.HTTPClient = srv.Client()
+
+		pingAndClose(t, opts)
+		require.Equal(t, srv.Client().Transport, opts.HTTPClient.Transport) // not modified
+	})
+}
+
+func httpTestOptions(t testing.TB, srvURL string) *Options {
+	u, err := url.Parse(srvURL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+	opts := DefaultOptions
+	opts.Host = host
+	opts.Port = port
+	opts.UseHTTP = true
+	return &opts
+}
+
+func pingAndClose(t *testing.T, opts *Options) {
+	conn, err := connect(context.Background(), opts, nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.Ping(context.Background()))
+	require.NoError(t, conn.Close())
+}
+
+// authRecorder records auth. details of requests and sets the auth cookie like Impala
+type authRecorder struct {
+	next http.Handler
+
+	mu        sync.Mutex
+	paths     []string
+	basicAuth []bool
+	cookie    []bool
+}
+
+func (a *authRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	_, _, hasBasicAuth := r.BasicAuth()
+	_, cookieErr := r.Cookie(httpAuthCookie)
+	a.paths = append(a.paths, r.URL.Path)
+	a.basicAuth = append(a.basicAuth, hasBasicAuth)
+	a.cookie = append(a.cookie, cookieErr == nil)
+	a.mu.Unlock()
+
+	if hasBasicAuth {
+		http.SetCookie(w, &http.Cookie{Name: httpAuthCookie, Value: "token"})
+	}
+	a.next.ServeHTTP(w, r)
+}
+
+func (a *authRecorder) distinctPaths() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return lo.Uniq(a.paths)
+}
@@ -1,9 +1,15 @@
 package impala
 
 import (
+	"context"
+	"crypto/tls"
 	"database/sql"
 	"io"
+	"net"
+	"net/http"
 	"time"
+
+	"github.com/sclgo/impala-go/internal/isql"
 )
 
 func init() {
@@ -21,6 +27,25 @@ type Options struct {
 	Username string
 	Password string
 
+	// ImpersonateUser makes sessions run queries as this user instead of the authenticated user (doAs).
+	// The authenticated user must be allowed to impersonate others with the Impala authorized_proxy_user_config flag.
+	// WithImpersonateUser overrides this setting for individual operations.
+	ImpersonateUser string
+
+	// Hosts lists Impala coordinators as host or host:port entries, where Port is the default port.
+	// If not empty, Host is ignored. The driver tries the hosts in the order selected by HostSelection,
+	// moving to the next one if a host is unreachable or fails the SASL handshake.
+	// With the HTTP transport, each host is probed with an unauthenticated HEAD request to find if it is reachable.
+	Hosts []string
+
+	// HostSelection is the order in which Hosts are tried. Default: HostSelectionOrdered
+	HostSelection HostSelection
+
+	// HostQuarantine is how long a host that failed to connect is tried only after all other hosts.
+	// Hosts are not quarantined if authentication failed or the context of the connection is done.
+	// 0 or negative value disables the quarantine.
+	HostQuarantine time.Duration
+
 	// ReuseSession disables resetting the session when database/sql SPI requests it.
 	// The connection and session will still be validated. database/sql asks to reset the session
 	// when it reuses a connection from its pool.
@@ -35,6 +60,15 @@ type Options struct {
 
 	UseLDAP bool
 
+	// UseKerberos enables Kerberos authentication with the GSSAPI SASL mechanism.
+	// The driver doesn't include a GSSAPI implementation - register one with RegisterSASLMechanism.
+	// Kerberos is supported only with the binary transport.
+	UseKerberos bool
+	// KerberosService is the service name in the Impala Kerberos principal. Default: impala
+	KerberosService string
+	// KerberosPrincipal is the client principal. If empty, the GSSAPI implementation chooses the credentials.
+	KerberosPrincipal string
+
 	UseTLS     bool
 	CACertPath string
 
@@ -42,8 +76,42 @@ type Options struct {
 	// a TLS connection to Impala. Behaves the same way as AllowSelfSignedCerts in the official JDBC driver.
 	TLSInsecureSkipVerify bool
 
+	// ClientCertPath and ClientKeyPath are the PEM files with the client certificate and private key, presented
+	// for mutual TLS authentication. The files are read again during a TLS handshake if they were modified
+	// since the last read, so rotated certificates are used in new connections without recreating the connector.
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// TLSServerName overrides the server name used to verify the server certificate and for SNI.
+	// By default, Host is used.
+	TLSServerName string
+
+	// TLSMinVersion is the minimum accepted TLS version e.g. tls.VersionTLS12. By default, the crypto/tls default is used.
+	TLSMinVersion uint16
+
+	// TLSConfig is used as-is for TLS connections if not nil. All other TLS-related fields, except UseTLS, are ignored.
+	TLSConfig *tls.Config
+	// clientCert, if not nil, is the reloader of the client certificate, shared by the connections of a connector
+	clientCert *clientCertReloader
+
 	BufferSize int
 	BatchSize  int
+	// BatchTargetBytes, if not 0, makes the driver adapt the number of rows per fetch for each query,
+	// starting from BatchSize, so that each batch has about this many bytes of decoded values.
+	// Batches shrink for wide rows and grow for narrow ones, within BatchSizeMin and BatchSizeMax rows.
+	// Each change is logged to LogOut.
+	BatchTargetBytes int
+	// BatchSizeMin and BatchSizeMax bound the number of rows per fetch if BatchTargetBytes is set.
+	// 0 means 16 and 65536 respectively. Connecting fails with ErrBadDSN if the minimum is greater than the maximum.
+	BatchSizeMin int
+	BatchSizeMax int
+	// BatchMaxFetchTime, if BatchTargetBytes is set, shrinks the batches of fetches that take longer,
+	// e.g. because of a slow network or a query that produces rows slowly. 0 means half of SocketTimeout.
+	BatchMaxFetchTime time.Duration
+	// Prefetch is the number of result batches, sized by BatchSize or BatchTargetBytes, that a background goroutine
+	// fetches ahead of the reader of the rows of a query, so that reading and network round-trips overlap.
+	// Progress functions are still called by the reader. 0 (the default) fetches each batch when the reader needs it.
+	Prefetch int
 
 	// MemoryLimit configures the MEM_LIMIT Impala property for the connection
 	// https://impala.apache.org/docs/build/html/topics/impala_mem_limit.html
@@ -52,6 +120,59 @@ type Options struct {
 	// https://impala.apache.org/docs/build/html/topics/impala_query_timeout_s.html
 	QueryTimeout int
 
+	// SessionConfig contains Impala query options, applied to each session when it is opened,
+	// as if a SET statement was executed for each entry. The map is not modified.
+	// MemoryLimit and QueryTimeout override the MEM_LIMIT and QUERY_TIMEOUT_S entries, if set.
+	// https://impala.apache.org/docs/build/html/topics/impala_query_options.html
+	SessionConfig map[string]string
+
+	// StrictSessionConfig makes opening a session fail with ErrOpenFailed if the server doesn't apply
+	// some SessionConfig entry e.g. because the option is unknown. It also makes statements fail with
+	// ErrUnknownQueryOption if the server doesn't know some option set by WithQueryOptions.
+	// By default, such entries and options are only logged.
+	StrictSessionConfig bool
+
+	// Database is the current database of each session. Opening a session fails with ErrOpenFailed
+	// if the database doesn't exist. Empty means the server default, usually "default".
+	Database string
+
+	// ProfileOnError attaches the runtime profile of failed queries to their errors.
+	// Use ProfileFromError to get it. Retrieving the profile costs an extra roundtrip for each failed query.
+	ProfileOnError bool
+
+	// FailOnRowErrors makes DML statements fail with ErrRowErrors if Kudu reports that some row operations failed
+	// e.g. because of primary key conflicts. Impala treats such errors as warnings, so by default they are only
+	// reported in the result - see WithDMLResultFunc. The statement still applies all other row operations.
+	FailOnRowErrors bool
+
+	// ProgressFunc, if not nil, receives the progress of queries while the driver waits for them.
+	// WithProgressFunc overrides this setting for individual operations.
+	ProgressFunc ProgressFunc
+
+	// QueryLogFunc, if not nil, receives the log of each query, with its warnings, before the query is closed.
+	// WithQueryLogFunc overrides this setting for individual operations.
+	QueryLogFunc QueryLogFunc
+
+	// MultiStatements makes connections split queries into statements at the semicolons outside string literals,
+	// quoted identifiers and comments, and run the statements one by one in the same session.
+	// Exec methods run all statements and return the totals of their results.
+	// Query methods return the rows of each statement that has a result set as a separate result set,
+	// running the statements in between when advancing with Rows.NextResultSet; closing the rows skips
+	// the remaining statements. Use StatementFromError to find which statement failed.
+	MultiStatements bool
+
+	// ExecMode selects if Exec methods wait for statements to finish. Default: ExecModeSync.
+	// WithExecMode overrides this setting for individual operations.
+	ExecMode ExecMode
+	// AsyncExecCloseTimeout is how long closing a connection waits for the statements that it started in
+	// ExecModeAsync to finish, before cancelling them. Default: 0, which leaves the statements running
+	// without waiting, and Impala closes their sessions after its disconnected_session_timeout.
+	AsyncExecCloseTimeout time.Duration
+
+	// Retry, if not nil, makes the driver run idempotent statements again after transport failures
+	// and retryable Impala errors. See RetryPolicy.
+	Retry *RetryPolicy
+
 	LogOut io.Writer
 
 	// TCP transport configuration
@@ -63,20 +184,84 @@ type Options struct {
 
 	// ConnectTimeout configures the max wait for initial connection to server. 0 or negative value means no limit.
 	ConnectTimeout time.Duration
+
+	// DialContext, if not nil, opens the network connections instead of net.Dialer e.g. to connect through a tunnel.
+	// ConnectTimeout is applied through the context. If Proxy is set, DialContext is used to connect to the proxy.
+	// Both the binary and the HTTP transports use DialContext, except when HTTPClient is set.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// Proxy is the URL of a proxy to connect through. Supported formats are socks5://[user:password@]host[:port]
+	// and http://[user:password@]host[:port] for proxies supporting the HTTP CONNECT method.
+	// socks5 resolves the host names of Impala locally. socks5h, like http, leaves that to the proxy.
+	Proxy string
+
+	// HTTP transport configuration
+
+	// UseHTTP selects the hs2-http Thrift transport instead of the default binary (TCP) transport.
+	// Impala serves hs2-http on port 28000 by default. UseLDAP sends the credentials as a basic auth header
+	// until the server returns an impala.auth cookie. UseTLS selects HTTPS.
+	UseHTTP bool
+
+	// HTTPPath is the URL path of the hs2-http endpoint.
+	HTTPPath string
+
+	// HTTPClient is the HTTP client used by the hs2-http transport. If nil, a client
+	// is created, observing ConnectTimeout and the TLS configuration. If not nil, the
+	// TLS configuration and ConnectTimeout are not applied - configure the client's transport instead.
+	// SocketTimeout does not apply to the HTTP transport - use the client Timeout field.
+	// The client is not modified. Each connection uses a shallow copy, whose transport wraps the client's transport
+	// and keeps the impala.auth cookie of the connection in memory, so connections don't share it.
+	// The Jar of the client, if any, is used as is and is shared by all connections.
+	HTTPClient *http.Client
+}
+
+// saslMechanism returns the name of the SASL mechanism selected by the options, or an empty string.
+func (o *Options) saslMechanism() string {
+	switch {
+	case o.UseKerberos:
+		return SASLMechGSSAPI
+	case o.UseLDAP:
+		return SASLMechPlain
+	}
+	return ""
 }
 
 func (o *Options) systemCAStoreSelected() bool {
-	return o.CACertPath == "" && !o.TLSInsecureSkipVerify
+	return o.TLSConfig == nil && o.CACertPath == "" && !o.TLSInsecureSkipVerify
 }
 
 var (
 	// DefaultOptions for impala driver
 	DefaultOptions = Options{
-		BatchSize:      1024,
-		BufferSize:     4096,
-		Port:           "21050",
-		LogOut:         io.Discard,
-		SocketTimeout:  5 * time.Second,
-		ConnectTimeout: 10 * time.Second,
+		BatchSize:       1024,
+		BufferSize:      4096,
+		Port:            "21050",
+		LogOut:          io.Discard,
+		SocketTimeout:   5 * time.Second,
+		ConnectTimeout:  10 * time.Second,
+		HTTPPath:        "cliservice",
+		KerberosService: "impala",
+		HostQuarantine:  30 * time.Second,
 	}
 )
+
+// DefaultHTTPPort is the default Impala hs2-http port. It is used instead of DefaultOptions.Port
+// when a DSN selects the HTTP transport without specifying a port.
+const DefaultHTTPPort = "28000"
+
+// WithQueryOptions returns a context that makes statements run with the given Impala query options,
+// e.g. map[string]string{"MT_DOP": "4"}. Unlike SessionConfig and SET statements, the options apply only to
+// each statement, so they don't leak to other statements when the session is reused. The options replace
+// those of any outer WithQueryOptions. The map is not modified and can be modified after the call.
+// With StrictSessionConfig, statements with options that the server doesn't know fail with ErrUnknownQueryOption
+// before they are sent. https://impala.apache.org/docs/build/html/topics/impala_query_options.html
+func WithQueryOptions(ctx context.Context, options map[string]string) context.Context {
+	return isql.WithQueryOptions(ctx, options)
+}
+
+// WithImpersonateUser returns a context that makes queries run as the given user, overriding Options.ImpersonateUser.
+// An empty user disables impersonation. The driver opens a new session when a connection, e.g. one reused from
+// the database/sql pool, has a session for a different user, so sessions are never shared between users.
+func WithImpersonateUser(ctx context.Context, user string) context.Context {
+	return isql.WithImpersonateUser(ctx, user)
+}
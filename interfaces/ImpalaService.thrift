@@ -19,6 +19,8 @@ namespace cpp impala
 namespace java com.cloudera.impala.thrift
 
 include "cli_service.thrift"
+include "ExecStats.thrift"
+include "RuntimeProfile.thrift"
 
 // The summary of a DML statement.
 struct TDmlResult {
@@ -94,34 +96,55 @@ struct TCloseImpalaOperationResp {
 
 // Impala HiveServer2 service
 
-// struct TGetExecSummaryReq {
-//   1: optional cli_service.TOperationHandle operationHandle
+struct TGetRuntimeProfileReq {
+  1: required cli_service.TOperationHandle operationHandle
+
+  2: required cli_service.TSessionHandle sessionHandle
+
+  3: optional RuntimeProfile.TRuntimeProfileFormat format =
+      RuntimeProfile.TRuntimeProfileFormat.STRING
+}
+
+struct TGetRuntimeProfileResp {
+  1: required cli_service.TStatus status
+
+  // Will be set on success if TGetRuntimeProfileReq.format
+  // was STRING, BASE64 or JSON.
+  2: optional string profile
 
-//   2: optional cli_service.TSessionHandle sessionHandle
+  // Will be set on success if TGetRuntimeProfileReq.format
+  // was THRIFT.
+  3: optional RuntimeProfile.TRuntimeProfileTree thrift_profile
+}
 
-//   // If true, returns the summaries of all query attempts. A TGetExecSummaryResp
-//   // always returns the profile for the most recent query attempt, regardless of the
-//   // query id specified. Clients should set this to true if they want to retrieve the
-//   // summaries of all query attempts (including the failed ones).
-//   3: optional bool include_query_attempts = false
-// }
+struct TGetExecSummaryReq {
+  1: optional cli_service.TOperationHandle operationHandle
 
-// struct TGetExecSummaryResp {
-//   1: required cli_service.TStatus status
+  2: optional cli_service.TSessionHandle sessionHandle
 
-//   2: optional ExecStats.TExecSummary summary
+  // If true, returns the summaries of all query attempts. A TGetExecSummaryResp
+  // always returns the profile for the most recent query attempt, regardless of the
+  // query id specified. Clients should set this to true if they want to retrieve the
+  // summaries of all query attempts (including the failed ones).
+  3: optional bool include_query_attempts = false
+}
 
-//   // A list of all summaries of the failed query attempts.
-//   3: optional list<ExecStats.TExecSummary> failed_summaries
-// }
+struct TGetExecSummaryResp {
+  1: required cli_service.TStatus status
+
+  2: optional ExecStats.TExecSummary summary
+
+  // A list of all summaries of the failed query attempts.
+  3: optional list<ExecStats.TExecSummary> failed_summaries
+}
 
 
 service ImpalaHiveServer2Service extends cli_service.TCLIService {
-  // // Returns the exec summary for the given query. The exec summary is only valid for
-  // // queries that execute with Impala's backend, i.e. QUERY, DML and COMPUTE_STATS
-  // // queries. Otherwise a default-initialized TExecSummary is returned for
-  // // backwards-compatibility with impala-shell - see IMPALA-9729.
-  // TGetExecSummaryResp GetExecSummary(1:TGetExecSummaryReq req);
+  // Returns the exec summary for the given query. The exec summary is only valid for
+  // queries that execute with Impala's backend, i.e. QUERY, DML and COMPUTE_STATS
+  // queries. Otherwise a default-initialized TExecSummary is returned for
+  // backwards-compatibility with impala-shell - see IMPALA-9729.
+  TGetExecSummaryResp GetExecSummary(1:TGetExecSummaryReq req);
 
   // Client calls this RPC to verify that the server is an ImpalaService. Returns the
   // server version.
@@ -129,4 +152,7 @@ service ImpalaHiveServer2Service extends cli_service.TCLIService {
 
   // Same as HS2 CloseOperation but can return additional information.
   TCloseImpalaOperationResp CloseImpalaOperation(1:TCloseImpalaOperationReq req);
+
+  // Returns the runtime profile string for the given query
+  TGetRuntimeProfileResp GetRuntimeProfile(1:TGetRuntimeProfileReq req);
 }
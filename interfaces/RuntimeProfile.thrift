@@ -0,0 +1,74 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+namespace cpp impala
+namespace java org.apache.impala.thrift
+
+include "Metrics.thrift"
+
+// Only the subset of the runtime profile definitions needed by the driver is kept here.
+// Fields that are not declared are skipped when reading profiles sent by the server.
+
+// The format of a runtime profile returned by GetRuntimeProfile().
+enum TRuntimeProfileFormat {
+  // Pretty printed.
+  STRING = 0
+
+  // The thrift profile, serialized, compressed, and encoded. Used for the query log.
+  // See RuntimeProfile::SerializeToArchiveString.
+  BASE64 = 1
+
+  // TRuntimeProfileTree.
+  THRIFT = 2
+
+  // JSON profile
+  JSON = 3
+}
+
+// Counter data
+struct TCounter {
+  1: required string name
+  2: required Metrics.TUnit unit
+  3: required i64 value
+}
+
+// A single runtime profile
+struct TRuntimeProfileNode {
+  1: required string name
+  2: required i32 num_children
+  // Counters is a list of flattened counters for this node and all its children
+  3: required list<TCounter> counters
+  // TODO: should we make metadata a map<string, string> or a list<string>?
+  4: required i64 metadata
+
+  // indicates whether the child will be printed with extra indentation;
+  // corresponds to indent param of RuntimeProfile::AddChild()
+  5: required bool indent
+
+  // map of key,value info strings that capture any kind of additional information
+  // about the profiled object
+  6: required map<string, string> info_strings
+
+  // Auxilliary structure to capture the info strings display order when printed
+  7: required list<string> info_strings_display_order
+}
+
+// A flattened tree of runtime profiles, obtained by an
+// pre-order traversal
+struct TRuntimeProfileTree {
+  1: required list<TRuntimeProfileNode> nodes
+}
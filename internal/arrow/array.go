@@ -0,0 +1,140 @@
+package arrow
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// The nulls arguments of the array constructors are null bitmaps like those of HiveServer2 columns:
+// the value at index i is null if bit i%8 of nulls[i/8] is set. Bits beyond the end of nulls are not set.
+// Arrow uses validity bitmaps instead, with the opposite meaning.
+
+// ErrOffsetOverflow means that the values of an array of a variable-length type don't fit in 32-bit offsets
+var ErrOffsetOverflow = errors.New("values too large for 32-bit offsets")
+
+// NullArray returns an array of n nulls
+func NullArray(n int) Array {
+	return Array{Len: n, NullCount: n}
+}
+
+// PrimitiveArray returns an array of fixed-width numbers
+func PrimitiveArray[T int8 | int16 | int32 | int64 | float32 | float64](values []T, nulls []byte) Array {
+	data, err := binary.Append(nil, binary.LittleEndian, values)
+	if err != nil {
+		// binary.Append supports all slices of fixed-width numbers
+		panic(err)
+	}
+	return newArray(len(values), nulls, data)
+}
+
+// BoolArray returns an array of booleans
+func BoolArray(values []bool, nulls []byte) Array {
+	data := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			data[i/8] |= 1 << (i % 8)
+		}
+	}
+	return newArray(len(values), nulls, data)
+}
+
+// VarBinaryArray returns an array of Utf8 or Binary values
+func VarBinaryArray[T string | []byte](values []T, nulls []byte) (Array, error) {
+	offsets := make([]byte, 0, 4*(len(values)+1))
+	offsets = binary.LittleEndian.AppendUint32(offsets, 0)
+	var size int
+	for _, v := range values {
+		size += len(v)
+		if size > math.MaxInt32 {
+			return Array{}, ErrOffsetOverflow
+		}
+		offsets = binary.LittleEndian.AppendUint32(offsets, uint32(size))
+	}
+	data := make([]byte, 0, size)
+	for _, v := range values {
+		data = append(data, v...)
+	}
+	a := newArray(len(values), nulls, offsets)
+	a.Buffers = append(a.Buffers, data)
+	return a, nil
+}
+
+// Decimal128Array returns an array of the decimal numbers in values, as text like "-12.50",
+// scaled to the given scale. Nulls may have any text.
+func Decimal128Array(values []string, nulls []byte, scale int) (Array, error) {
+	data := make([]byte, 16*len(values))
+	for i, v := range values {
+		if isNull(nulls, i) {
+			continue
+		}
+		if err := putDecimal128(data[16*i:16*i+16], v, scale); err != nil {
+			return Array{}, err
+		}
+	}
+	return newArray(len(values), nulls, data), nil
+}
+
+var (
+	maxDecimal128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minDecimal128 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	twoTo128      = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+// putDecimal128 puts the unscaled value of the decimal text s in dst as a little-endian 128-bit two's complement
+func putDecimal128(dst []byte, s string, scale int) error {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > scale {
+		return fmt.Errorf("decimal %s has more than %d digits after the decimal point", s, scale)
+	}
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart+strings.Repeat("0", scale-len(fracPart)), 10)
+	if !ok {
+		return fmt.Errorf("invalid decimal %s", s)
+	}
+	if unscaled.Cmp(maxDecimal128) > 0 || unscaled.Cmp(minDecimal128) < 0 {
+		return fmt.Errorf("decimal %s does not fit in 128 bits", s)
+	}
+	if unscaled.Sign() < 0 {
+		unscaled.Add(unscaled, twoTo128)
+	}
+	var be [16]byte
+	unscaled.FillBytes(be[:])
+	for i := range 16 {
+		dst[i] = be[15-i]
+	}
+	return nil
+}
+
+// newArray returns an array with the validity bitmap for nulls and the data buffer
+func newArray(n int, nulls []byte, data []byte) Array {
+	validity, nullCount := validityBitmap(nulls, n)
+	return Array{Len: n, NullCount: nullCount, Buffers: [][]byte{validity, data}}
+}
+
+// validityBitmap returns the validity bitmap of n values with the given null bitmap and the number of nulls.
+// The bitmap is empty if there are no nulls.
+func validityBitmap(nulls []byte, n int) ([]byte, int) {
+	var nullCount int
+	for i := 0; i < n && i/8 < len(nulls); i++ {
+		if isNull(nulls, i) {
+			nullCount++
+		}
+	}
+	if nullCount == 0 {
+		return nil, 0
+	}
+	validity := make([]byte, (n+7)/8)
+	for i := range n {
+		if !isNull(nulls, i) {
+			validity[i/8] |= 1 << (i % 8)
+		}
+	}
+	return validity, nullCount
+}
+
+func isNull(nulls []byte, i int) bool {
+	return i/8 < len(nulls) && nulls[i/8]&(1<<(i%8)) != 0
+}
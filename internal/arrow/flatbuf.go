@@ -0,0 +1,138 @@
+package arrow
+
+import (
+	"encoding/binary"
+)
+
+// table is a flatbuffers table. Its fields are indexed by field ID. Zero fields are absent.
+type table []field
+
+// field is a flatbuffers table field: either an inline scalar or a reference to a string, table or vector
+type field struct {
+	scalar []byte
+	ref    any
+}
+
+// structs is a vector of structs, with 8-byte alignment, like the FieldNode and Buffer structs of Arrow
+type structs struct {
+	count int
+	data  []byte
+}
+
+func int16Field(v int16) field {
+	return field{scalar: binary.LittleEndian.AppendUint16(nil, uint16(v))}
+}
+
+func int32Field(v int32) field {
+	return field{scalar: binary.LittleEndian.AppendUint32(nil, uint32(v))}
+}
+
+func int64Field(v int64) field {
+	return field{scalar: binary.LittleEndian.AppendUint64(nil, uint64(v))}
+}
+
+func byteField(v byte) field {
+	return field{scalar: []byte{v}}
+}
+
+func boolField(v bool) field {
+	if v {
+		return byteField(1)
+	}
+	return byteField(0)
+}
+
+// refField refers to a string, a *table, a []*table or structs
+func refField(v any) field {
+	return field{ref: v}
+}
+
+// builder serializes flatbuffers top-down: each object is written before the objects that it refers to,
+// because references are unsigned offsets, which must point forward. Scalars are aligned to their size
+// relative to the start of the buffer.
+type builder struct {
+	buf []byte
+}
+
+// finish returns the flatbuffer with root as the root table, padded to a multiple of 8 bytes
+func (b *builder) finish(root *table) []byte {
+	b.buf = make([]byte, 4)
+	b.writeRef(0, root)
+	b.pad(8)
+	return b.buf
+}
+
+func (b *builder) pad(align int) {
+	for len(b.buf)%align != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+// writeRef writes obj and sets the reference at pos to it
+func (b *builder) writeRef(pos int, obj any) {
+	var at int
+	switch obj := obj.(type) {
+	case *table:
+		at = b.writeTable(*obj)
+	case string:
+		b.pad(4)
+		at = len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(obj)))
+		b.buf = append(b.buf, obj...)
+		b.buf = append(b.buf, 0)
+	case []*table:
+		b.pad(4)
+		at = len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(obj)))
+		slots := len(b.buf)
+		b.buf = append(b.buf, make([]byte, 4*len(obj))...)
+		for i, t := range obj {
+			b.writeRef(slots+4*i, t)
+		}
+	case structs:
+		// the structs after the length must be 8-byte aligned
+		for len(b.buf)%8 != 4 {
+			b.buf = append(b.buf, 0)
+		}
+		at = len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(obj.count))
+		b.buf = append(b.buf, obj.data...)
+	default:
+		panic("unsupported flatbuffers reference")
+	}
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(at-pos))
+}
+
+// writeTable writes the vtable and the table and then the objects that the table refers to.
+// It returns the position of the table.
+func (b *builder) writeTable(t table) int {
+	b.pad(2)
+	vtable := len(b.buf)
+	vtableSize := 4 + 2*len(t)
+	b.buf = append(b.buf, make([]byte, vtableSize)...)
+
+	b.pad(4)
+	start := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(int32(start-vtable)))
+	var refs []int
+	for id, f := range t {
+		switch {
+		case f.scalar != nil:
+			b.pad(len(f.scalar))
+			binary.LittleEndian.PutUint16(b.buf[vtable+4+2*id:], uint16(len(b.buf)-start))
+			b.buf = append(b.buf, f.scalar...)
+		case f.ref != nil:
+			b.pad(4)
+			binary.LittleEndian.PutUint16(b.buf[vtable+4+2*id:], uint16(len(b.buf)-start))
+			refs = append(refs, id, len(b.buf))
+			b.buf = append(b.buf, 0, 0, 0, 0)
+		}
+	}
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(vtableSize))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(len(b.buf)-start))
+
+	for i := 0; i < len(refs); i += 2 {
+		b.writeRef(refs[i+1], t[refs[i]].ref)
+	}
+	return start
+}
@@ -0,0 +1,206 @@
+// Package arrow writes Apache Arrow IPC streams with the standard library only.
+// It supports the flat types that Impala query results can have.
+// Docs: https://arrow.apache.org/docs/format/Columnar.html#serialization-and-interprocess-communication-ipc
+package arrow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// codes of the Type union of Schema.fbs
+const (
+	typeNull          = 1
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeBinary        = 4
+	typeUtf8          = 5
+	typeBool          = 6
+	typeDecimal       = 7
+	typeDate          = 8
+	typeTimestamp     = 10
+)
+
+// codes of the MessageHeader union of Message.fbs
+const (
+	headerSchema      = 1
+	headerRecordBatch = 3
+)
+
+// metadataVersionV5 is the V5 value of the MetadataVersion enum
+const metadataVersionV5 = 4
+
+// Type is an Arrow data type
+type Type struct {
+	code   byte
+	fields table
+	// buffers is the number of buffers of arrays of the type
+	buffers int
+	name    string
+}
+
+func (t Type) String() string {
+	return t.name
+}
+
+// Null is the type of arrays that have only nulls
+var Null = Type{code: typeNull, name: "null"}
+
+// Int returns the type of integers with the given bit width
+func Int(bitWidth int, signed bool) Type {
+	name := fmt.Sprintf("int%d", bitWidth)
+	if !signed {
+		name = "u" + name
+	}
+	return Type{code: typeInt, fields: table{int32Field(int32(bitWidth)), boolField(signed)}, buffers: 2, name: name}
+}
+
+var (
+	// Float32 is the type of single precision floating point numbers
+	Float32 = Type{code: typeFloatingPoint, fields: table{int16Field(1)}, buffers: 2, name: "float32"}
+	// Float64 is the type of double precision floating point numbers
+	Float64 = Type{code: typeFloatingPoint, fields: table{int16Field(2)}, buffers: 2, name: "float64"}
+	// Binary is the type of byte strings, with 32-bit offsets
+	Binary = Type{code: typeBinary, buffers: 3, name: "binary"}
+	// Utf8 is the type of UTF-8 strings, with 32-bit offsets
+	Utf8 = Type{code: typeUtf8, buffers: 3, name: "utf8"}
+	// Bool is the type of booleans, packed as bits
+	Bool = Type{code: typeBool, buffers: 2, name: "bool"}
+	// Date32 is the type of dates as 32-bit numbers of days since the UNIX epoch
+	Date32 = Type{code: typeDate, fields: table{int16Field(0)}, buffers: 2, name: "date32"}
+	// TimestampNano is the type of timestamps without time zone as 64-bit numbers of nanoseconds since the UNIX epoch
+	TimestampNano = Type{code: typeTimestamp, fields: table{int16Field(3)}, buffers: 2, name: "timestamp[ns]"}
+)
+
+// Decimal128 returns the type of decimal numbers with the given precision and scale,
+// as 128-bit two's complement unscaled integers
+func Decimal128(precision, scale int) Type {
+	return Type{
+		code:    typeDecimal,
+		fields:  table{int32Field(int32(precision)), int32Field(int32(scale)), int32Field(128)},
+		buffers: 2,
+		name:    fmt.Sprintf("decimal128(%d, %d)", precision, scale),
+	}
+}
+
+// Field is a column of a schema
+type Field struct {
+	Name     string
+	Type     Type
+	Nullable bool
+}
+
+// Array is the data of a column of a record batch
+type Array struct {
+	// Len is the number of values
+	Len int
+	// NullCount is the number of nulls
+	NullCount int
+	// Buffers are the buffers of the array in the order of the Arrow columnar format, e.g. the validity bitmap
+	// and the values. The validity bitmap may be empty if there are no nulls.
+	Buffers [][]byte
+}
+
+// Writer writes an Arrow IPC stream
+type Writer struct {
+	w      io.Writer
+	fields []Field
+}
+
+// NewWriter writes the schema with the given fields to w and returns a writer of the record batches
+func NewWriter(w io.Writer, fields []Field) (*Writer, error) {
+	schemaFields := make([]*table, len(fields))
+	for i, f := range fields {
+		typeFields := f.Type.fields
+		if typeFields == nil {
+			typeFields = table{}
+		}
+		schemaFields[i] = &table{
+			refField(f.Name),
+			boolField(f.Nullable),
+			byteField(f.Type.code),
+			refField(&typeFields),
+			{},
+			// readers require the children, even if there are none
+			refField([]*table{}),
+		}
+	}
+	schema := &table{int16Field(0), refField(schemaFields)}
+	aw := &Writer{w: w, fields: fields}
+	return aw, aw.writeMessage(headerSchema, schema, nil)
+}
+
+// Write writes a record batch with the given number of rows and an array for each field
+func (aw *Writer) Write(length int, arrays []Array) error {
+	if len(arrays) != len(aw.fields) {
+		return fmt.Errorf("got %d arrays for %d fields", len(arrays), len(aw.fields))
+	}
+	var nodes, buffers []byte
+	var body [][]byte
+	var offset int
+	for i, a := range arrays {
+		if a.Len != length {
+			return fmt.Errorf("array %d has %d values instead of %d", i, a.Len, length)
+		}
+		if len(a.Buffers) != aw.fields[i].Type.buffers {
+			return fmt.Errorf("array %d of type %s has %d buffers instead of %d", i, aw.fields[i].Type,
+				len(a.Buffers), aw.fields[i].Type.buffers)
+		}
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(a.Len))
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(a.NullCount))
+		for _, buf := range a.Buffers {
+			buffers = binary.LittleEndian.AppendUint64(buffers, uint64(offset))
+			buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(buf)))
+			body = append(body, buf)
+			offset += padded(len(buf))
+		}
+	}
+	batch := &table{
+		int64Field(int64(length)),
+		refField(structs{count: len(arrays), data: nodes}),
+		refField(structs{count: len(buffers) / 16, data: buffers}),
+	}
+	return aw.writeMessage(headerRecordBatch, batch, body)
+}
+
+// Close writes the end of the stream. It doesn't close the underlying writer.
+func (aw *Writer) Close() error {
+	_, err := aw.w.Write([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0})
+	return err
+}
+
+// writeMessage writes an encapsulated message with the given header and the body buffers, each padded
+// to a multiple of 8 bytes
+func (aw *Writer) writeMessage(headerType byte, header *table, body [][]byte) error {
+	var bodyLength int
+	for _, buf := range body {
+		bodyLength += padded(len(buf))
+	}
+	var b builder
+	metadata := b.finish(&table{
+		int16Field(metadataVersionV5),
+		byteField(headerType),
+		refField(header),
+		int64Field(int64(bodyLength)),
+	})
+
+	prefix := binary.LittleEndian.AppendUint32([]byte{0xff, 0xff, 0xff, 0xff}, uint32(len(metadata)))
+	if _, err := aw.w.Write(append(prefix, metadata...)); err != nil {
+		return err
+	}
+	var zeros [8]byte
+	for _, buf := range body {
+		if _, err := aw.w.Write(buf); err != nil {
+			return err
+		}
+		if _, err := aw.w.Write(zeros[:padded(len(buf))-len(buf)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func padded(n int) int {
+	return (n + 7) &^ 7
+}
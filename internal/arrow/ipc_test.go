@@ -0,0 +1,249 @@
+package arrow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(&out, []Field{
+		{Name: "i", Type: Int(32, true), Nullable: true},
+		{Name: "s", Type: Utf8, Nullable: true},
+		{Name: "d", Type: Decimal128(10, 2)},
+		{Name: "b", Type: Bool, Nullable: true},
+		{Name: "n", Type: Null, Nullable: true},
+	})
+	require.NoError(t, err)
+
+	// the second value is null
+	nulls := []byte{0b010}
+	strs, err := VarBinaryArray([]string{"a", "", "bc"}, nulls)
+	require.NoError(t, err)
+	decimals, err := Decimal128Array([]string{"1.5", "0", "-0.25"}, nil, 2)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(3, []Array{
+		PrimitiveArray([]int32{1, 0, -1}, nulls),
+		strs,
+		decimals,
+		BoolArray([]bool{true, false, true}, nulls),
+		NullArray(3),
+	}))
+	require.NoError(t, w.Close())
+
+	messages, rest := readStream(t, out.Bytes())
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0}, rest)
+	require.Len(t, messages, 2)
+
+	schema := messages[0]
+	require.EqualValues(t, metadataVersionV5, schema.meta.int16(0))
+	require.EqualValues(t, headerSchema, schema.meta.byte(1))
+	require.Zero(t, schema.meta.int64(3))
+	fields := schema.meta.table(2).tables(1)
+	require.Len(t, fields, 5)
+	names := []string{"i", "s", "d", "b", "n"}
+	codes := []byte{typeInt, typeUtf8, typeDecimal, typeBool, typeNull}
+	for i, f := range fields {
+		require.Equal(t, names[i], f.string(0))
+		require.Equal(t, names[i] != "d", f.byte(1) == 1)
+		require.Equal(t, codes[i], f.byte(2))
+		require.Empty(t, f.tables(5))
+	}
+	require.EqualValues(t, 32, fields[0].table(3).int32(0))
+	require.EqualValues(t, 1, fields[0].table(3).byte(1))
+	decimal := fields[2].table(3)
+	require.EqualValues(t, []int32{10, 2, 128}, []int32{decimal.int32(0), decimal.int32(1), decimal.int32(2)})
+
+	batch := messages[1]
+	require.EqualValues(t, headerRecordBatch, batch.meta.byte(1))
+	require.EqualValues(t, len(batch.body), batch.meta.int64(3))
+	rb := batch.meta.table(2)
+	require.EqualValues(t, 3, rb.int64(0))
+	nodes := rb.structs(1, 16)
+	require.Equal(t, [][2]int64{{3, 1}, {3, 1}, {3, 0}, {3, 1}, {3, 3}}, pairs(nodes))
+
+	var buffers [][]byte
+	for _, b := range pairs(rb.structs(2, 16)) {
+		require.Zero(t, b[0]%8, "buffer offsets are 8-byte aligned")
+		buffers = append(buffers, batch.body[b[0]:b[0]+b[1]])
+	}
+	// a null array has no buffers
+	require.Len(t, buffers, 9)
+	validity := []byte{0b101}
+	require.Equal(t, validity, buffers[0])
+	require.Equal(t, binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(
+		binary.LittleEndian.AppendUint32(nil, 1), 0), math.MaxUint32), buffers[1])
+	require.Equal(t, validity, buffers[2])
+	require.Equal(t, []byte{0, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 3, 0, 0, 0}, buffers[3])
+	require.Equal(t, []byte("abc"), buffers[4])
+	require.Empty(t, buffers[5])
+	require.Equal(t, decimal128Bytes(150, 0, -25), buffers[6])
+	require.Equal(t, validity, buffers[7])
+	require.Equal(t, []byte{0b101}, buffers[8])
+
+	require.ErrorContains(t, w.Write(3, nil), "got 0 arrays for 5 fields")
+}
+
+func TestDecimal128(t *testing.T) {
+	tests := []struct {
+		text     string
+		scale    int
+		unscaled int64
+	}{
+		{"-12.50", 2, -1250},
+		{"1.5", 3, 1500},
+		{"-.5", 1, -5},
+		{"42", 0, 42},
+		{"-0", 2, 0},
+	}
+	for _, tt := range tests {
+		a, err := Decimal128Array([]string{tt.text}, nil, tt.scale)
+		require.NoError(t, err, tt.text)
+		require.Equal(t, decimal128Bytes(tt.unscaled), a.Buffers[1], tt.text)
+	}
+
+	max38 := "99999999999999999999999999999999999999"
+	a, err := Decimal128Array([]string{max38, "-" + max38}, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0x3f, 0x22, 0x8a, 0x09, 0x7a, 0xc4, 0x86, 0x5a, 0xa8, 0x4c, 0x3b, 0x4b}, a.Buffers[1][:16])
+	require.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0xc0, 0xdd, 0x75, 0xf6, 0x85, 0x3b, 0x79, 0xa5, 0x57, 0xb3, 0xc4, 0xb4}, a.Buffers[1][16:])
+
+	for _, invalid := range []string{"1.234", "1e5", "abc", max38 + "0000"} {
+		_, err = Decimal128Array([]string{invalid}, nil, 2)
+		require.Error(t, err, invalid)
+	}
+	// nulls are not parsed
+	_, err = Decimal128Array([]string{"", "abc"}, []byte{0b11}, 2)
+	require.NoError(t, err)
+}
+
+func decimal128Bytes(unscaled ...int64) []byte {
+	var data []byte
+	for _, v := range unscaled {
+		data = binary.LittleEndian.AppendUint64(data, uint64(v))
+		data = binary.LittleEndian.AppendUint64(data, uint64(v>>63))
+	}
+	return data
+}
+
+func pairs(data []byte) [][2]int64 {
+	var result [][2]int64
+	for i := 0; i < len(data); i += 16 {
+		result = append(result, [2]int64{
+			int64(binary.LittleEndian.Uint64(data[i:])),
+			int64(binary.LittleEndian.Uint64(data[i+8:])),
+		})
+	}
+	return result
+}
+
+type message struct {
+	meta fbTable
+	body []byte
+}
+
+// readStream reads the encapsulated messages of an Arrow IPC stream, until the end-of-stream marker,
+// which is returned with the rest of the stream
+func readStream(t *testing.T, stream []byte) ([]message, []byte) {
+	var messages []message
+	for {
+		require.GreaterOrEqual(t, len(stream), 8)
+		require.Equal(t, uint32(0xffffffff), binary.LittleEndian.Uint32(stream))
+		size := int(binary.LittleEndian.Uint32(stream[4:]))
+		if size == 0 {
+			return messages, stream
+		}
+		require.Zero(t, size%8, "messages are 8-byte aligned")
+		meta := fbTable{t: t, buf: stream[8 : 8+size]}
+		meta.pos = int(meta.uint32(0))
+		bodyLength := int(meta.int64(3))
+		messages = append(messages, message{meta: meta, body: stream[8+size : 8+size+bodyLength]})
+		stream = stream[8+size+bodyLength:]
+	}
+}
+
+// fbTable reads a flatbuffers table and checks the alignment of what it reads
+type fbTable struct {
+	t   *testing.T
+	buf []byte
+	pos int
+}
+
+func (f fbTable) aligned(pos, size int) int {
+	require.Zero(f.t, pos%size, "position %d is not aligned to %d", pos, size)
+	require.LessOrEqual(f.t, pos+size, len(f.buf))
+	return pos
+}
+
+func (f fbTable) uint32(pos int) uint32 {
+	return binary.LittleEndian.Uint32(f.buf[f.aligned(pos, 4):])
+}
+
+// field returns the position of the field with the given ID, or -1 if it is absent
+func (f fbTable) field(id int) int {
+	vtable := f.pos - int(int32(f.uint32(f.pos)))
+	vtableSize := int(binary.LittleEndian.Uint16(f.buf[f.aligned(vtable, 2):]))
+	if 4+2*id >= vtableSize {
+		return -1
+	}
+	offset := int(binary.LittleEndian.Uint16(f.buf[vtable+4+2*id:]))
+	if offset == 0 {
+		return -1
+	}
+	return f.pos + offset
+}
+
+func (f fbTable) byte(id int) byte {
+	return f.buf[f.field(id)]
+}
+
+func (f fbTable) int16(id int) int16 {
+	return int16(binary.LittleEndian.Uint16(f.buf[f.aligned(f.field(id), 2):]))
+}
+
+func (f fbTable) int32(id int) int32 {
+	return int32(f.uint32(f.field(id)))
+}
+
+func (f fbTable) int64(id int) int64 {
+	return int64(binary.LittleEndian.Uint64(f.buf[f.aligned(f.field(id), 8):]))
+}
+
+func (f fbTable) ref(id int) int {
+	pos := f.field(id)
+	require.GreaterOrEqual(f.t, pos, 0, "field %d is absent", id)
+	return pos + int(f.uint32(pos))
+}
+
+func (f fbTable) table(id int) fbTable {
+	return fbTable{t: f.t, buf: f.buf, pos: f.aligned(f.ref(id), 4)}
+}
+
+func (f fbTable) string(id int) string {
+	pos := f.ref(id)
+	n := int(f.uint32(pos))
+	require.Zero(f.t, f.buf[pos+4+n], "strings are null-terminated")
+	return string(f.buf[pos+4 : pos+4+n])
+}
+
+func (f fbTable) tables(id int) []fbTable {
+	pos := f.ref(id)
+	n := int(f.uint32(pos))
+	result := make([]fbTable, n)
+	for i := range result {
+		slot := pos + 4 + 4*i
+		result[i] = fbTable{t: f.t, buf: f.buf, pos: f.aligned(slot+int(f.uint32(slot)), 4)}
+	}
+	return result
+}
+
+// structs returns the data of a vector of structs of the given size, with 8-byte alignment
+func (f fbTable) structs(id int, size int) []byte {
+	pos := f.ref(id)
+	n := int(f.uint32(pos))
+	return f.buf[f.aligned(pos+4, 8) : pos+4+n*size]
+}
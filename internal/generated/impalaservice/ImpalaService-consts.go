@@ -14,6 +14,8 @@ import (
 	"strings"
 	"regexp"
 	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/generated/execstats"
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
 
 )
 
@@ -31,6 +33,8 @@ var _ = strings.Contains
 var _ = regexp.MatchString
 
 var _ = cli_service.GoUnusedProtection__
+var _ = execstats.GoUnusedProtection__
+var _ = runtimeprofile.GoUnusedProtection__
 
 func init() {
 }
@@ -14,18 +14,24 @@ import (
 	"strings"
 	thrift "github.com/apache/thrift/lib/go/thrift"
 	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/generated/execstats"
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
 	"github.com/sclgo/impala-go/internal/generated/impalaservice"
 )
 
 var _ = cli_service.GoUnusedProtection__
+var _ = execstats.GoUnusedProtection__
+var _ = runtimeprofile.GoUnusedProtection__
 var _ = impalaservice.GoUnusedProtection__
 
 func Usage() {
 	fmt.Fprintln(os.Stderr, "Usage of ", os.Args[0], " [-h host:port] [-u url] [-f[ramed]] function [arg1 [arg2...]]:")
 	flag.PrintDefaults()
 	fmt.Fprintln(os.Stderr, "\nFunctions:")
+	fmt.Fprintln(os.Stderr, "  TGetExecSummaryResp GetExecSummary(TGetExecSummaryReq req)")
 	fmt.Fprintln(os.Stderr, "  TPingImpalaHS2ServiceResp PingImpalaHS2Service(TPingImpalaHS2ServiceReq req)")
 	fmt.Fprintln(os.Stderr, "  TCloseImpalaOperationResp CloseImpalaOperation(TCloseImpalaOperationReq req)")
+	fmt.Fprintln(os.Stderr, "  TGetRuntimeProfileResp GetRuntimeProfile(TGetRuntimeProfileReq req)")
 	fmt.Fprintln(os.Stderr, "  TOpenSessionResp OpenSession(TOpenSessionReq req)")
 	fmt.Fprintln(os.Stderr, "  TCloseSessionResp CloseSession(TCloseSessionReq req)")
 	fmt.Fprintln(os.Stderr, "  TGetInfoResp GetInfo(TGetInfoReq req)")
@@ -160,9 +166,9 @@ func main() {
 	}
 	
 	switch cmd {
-	case "PingImpalaHS2Service":
+	case "GetExecSummary":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "PingImpalaHS2Service requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetExecSummary requires 1 args")
 			flag.Usage()
 		}
 		arg19 := flag.Arg(1)
@@ -175,19 +181,19 @@ func main() {
 		}
 		factory22 := thrift.NewTJSONProtocolFactory()
 		jsProt23 := factory22.GetProtocol(mbTrans20)
-		argvalue0 := impalaservice.NewTPingImpalaHS2ServiceReq()
+		argvalue0 := impalaservice.NewTGetExecSummaryReq()
 		err24 := argvalue0.Read(context.Background(), jsProt23)
 		if err24 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.PingImpalaHS2Service(context.Background(), value0))
+		fmt.Print(client.GetExecSummary(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "CloseImpalaOperation":
+	case "PingImpalaHS2Service":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "CloseImpalaOperation requires 1 args")
+			fmt.Fprintln(os.Stderr, "PingImpalaHS2Service requires 1 args")
 			flag.Usage()
 		}
 		arg25 := flag.Arg(1)
@@ -200,19 +206,19 @@ func main() {
 		}
 		factory28 := thrift.NewTJSONProtocolFactory()
 		jsProt29 := factory28.GetProtocol(mbTrans26)
-		argvalue0 := impalaservice.NewTCloseImpalaOperationReq()
+		argvalue0 := impalaservice.NewTPingImpalaHS2ServiceReq()
 		err30 := argvalue0.Read(context.Background(), jsProt29)
 		if err30 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.CloseImpalaOperation(context.Background(), value0))
+		fmt.Print(client.PingImpalaHS2Service(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "OpenSession":
+	case "CloseImpalaOperation":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "OpenSession requires 1 args")
+			fmt.Fprintln(os.Stderr, "CloseImpalaOperation requires 1 args")
 			flag.Usage()
 		}
 		arg31 := flag.Arg(1)
@@ -225,19 +231,19 @@ func main() {
 		}
 		factory34 := thrift.NewTJSONProtocolFactory()
 		jsProt35 := factory34.GetProtocol(mbTrans32)
-		argvalue0 := cli_service.NewTOpenSessionReq()
+		argvalue0 := impalaservice.NewTCloseImpalaOperationReq()
 		err36 := argvalue0.Read(context.Background(), jsProt35)
 		if err36 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.OpenSession(context.Background(), value0))
+		fmt.Print(client.CloseImpalaOperation(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "CloseSession":
+	case "GetRuntimeProfile":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "CloseSession requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetRuntimeProfile requires 1 args")
 			flag.Usage()
 		}
 		arg37 := flag.Arg(1)
@@ -250,19 +256,19 @@ func main() {
 		}
 		factory40 := thrift.NewTJSONProtocolFactory()
 		jsProt41 := factory40.GetProtocol(mbTrans38)
-		argvalue0 := cli_service.NewTCloseSessionReq()
+		argvalue0 := impalaservice.NewTGetRuntimeProfileReq()
 		err42 := argvalue0.Read(context.Background(), jsProt41)
 		if err42 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.CloseSession(context.Background(), value0))
+		fmt.Print(client.GetRuntimeProfile(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetInfo":
+	case "OpenSession":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetInfo requires 1 args")
+			fmt.Fprintln(os.Stderr, "OpenSession requires 1 args")
 			flag.Usage()
 		}
 		arg43 := flag.Arg(1)
@@ -275,19 +281,19 @@ func main() {
 		}
 		factory46 := thrift.NewTJSONProtocolFactory()
 		jsProt47 := factory46.GetProtocol(mbTrans44)
-		argvalue0 := cli_service.NewTGetInfoReq()
+		argvalue0 := cli_service.NewTOpenSessionReq()
 		err48 := argvalue0.Read(context.Background(), jsProt47)
 		if err48 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetInfo(context.Background(), value0))
+		fmt.Print(client.OpenSession(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "ExecuteStatement":
+	case "CloseSession":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "ExecuteStatement requires 1 args")
+			fmt.Fprintln(os.Stderr, "CloseSession requires 1 args")
 			flag.Usage()
 		}
 		arg49 := flag.Arg(1)
@@ -300,19 +306,19 @@ func main() {
 		}
 		factory52 := thrift.NewTJSONProtocolFactory()
 		jsProt53 := factory52.GetProtocol(mbTrans50)
-		argvalue0 := cli_service.NewTExecuteStatementReq()
+		argvalue0 := cli_service.NewTCloseSessionReq()
 		err54 := argvalue0.Read(context.Background(), jsProt53)
 		if err54 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.ExecuteStatement(context.Background(), value0))
+		fmt.Print(client.CloseSession(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetCatalogs":
+	case "GetInfo":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetCatalogs requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetInfo requires 1 args")
 			flag.Usage()
 		}
 		arg55 := flag.Arg(1)
@@ -325,19 +331,19 @@ func main() {
 		}
 		factory58 := thrift.NewTJSONProtocolFactory()
 		jsProt59 := factory58.GetProtocol(mbTrans56)
-		argvalue0 := cli_service.NewTGetCatalogsReq()
+		argvalue0 := cli_service.NewTGetInfoReq()
 		err60 := argvalue0.Read(context.Background(), jsProt59)
 		if err60 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetCatalogs(context.Background(), value0))
+		fmt.Print(client.GetInfo(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetSchemas":
+	case "ExecuteStatement":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetSchemas requires 1 args")
+			fmt.Fprintln(os.Stderr, "ExecuteStatement requires 1 args")
 			flag.Usage()
 		}
 		arg61 := flag.Arg(1)
@@ -350,19 +356,19 @@ func main() {
 		}
 		factory64 := thrift.NewTJSONProtocolFactory()
 		jsProt65 := factory64.GetProtocol(mbTrans62)
-		argvalue0 := cli_service.NewTGetSchemasReq()
+		argvalue0 := cli_service.NewTExecuteStatementReq()
 		err66 := argvalue0.Read(context.Background(), jsProt65)
 		if err66 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetSchemas(context.Background(), value0))
+		fmt.Print(client.ExecuteStatement(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetTables":
+	case "GetCatalogs":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetTables requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetCatalogs requires 1 args")
 			flag.Usage()
 		}
 		arg67 := flag.Arg(1)
@@ -375,19 +381,19 @@ func main() {
 		}
 		factory70 := thrift.NewTJSONProtocolFactory()
 		jsProt71 := factory70.GetProtocol(mbTrans68)
-		argvalue0 := cli_service.NewTGetTablesReq()
+		argvalue0 := cli_service.NewTGetCatalogsReq()
 		err72 := argvalue0.Read(context.Background(), jsProt71)
 		if err72 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetTables(context.Background(), value0))
+		fmt.Print(client.GetCatalogs(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetTableTypes":
+	case "GetSchemas":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetTableTypes requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetSchemas requires 1 args")
 			flag.Usage()
 		}
 		arg73 := flag.Arg(1)
@@ -400,19 +406,19 @@ func main() {
 		}
 		factory76 := thrift.NewTJSONProtocolFactory()
 		jsProt77 := factory76.GetProtocol(mbTrans74)
-		argvalue0 := cli_service.NewTGetTableTypesReq()
+		argvalue0 := cli_service.NewTGetSchemasReq()
 		err78 := argvalue0.Read(context.Background(), jsProt77)
 		if err78 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetTableTypes(context.Background(), value0))
+		fmt.Print(client.GetSchemas(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetColumns":
+	case "GetTables":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetColumns requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetTables requires 1 args")
 			flag.Usage()
 		}
 		arg79 := flag.Arg(1)
@@ -425,19 +431,19 @@ func main() {
 		}
 		factory82 := thrift.NewTJSONProtocolFactory()
 		jsProt83 := factory82.GetProtocol(mbTrans80)
-		argvalue0 := cli_service.NewTGetColumnsReq()
+		argvalue0 := cli_service.NewTGetTablesReq()
 		err84 := argvalue0.Read(context.Background(), jsProt83)
 		if err84 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetColumns(context.Background(), value0))
+		fmt.Print(client.GetTables(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetFunctions":
+	case "GetTableTypes":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetFunctions requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetTableTypes requires 1 args")
 			flag.Usage()
 		}
 		arg85 := flag.Arg(1)
@@ -450,19 +456,19 @@ func main() {
 		}
 		factory88 := thrift.NewTJSONProtocolFactory()
 		jsProt89 := factory88.GetProtocol(mbTrans86)
-		argvalue0 := cli_service.NewTGetFunctionsReq()
+		argvalue0 := cli_service.NewTGetTableTypesReq()
 		err90 := argvalue0.Read(context.Background(), jsProt89)
 		if err90 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetFunctions(context.Background(), value0))
+		fmt.Print(client.GetTableTypes(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetOperationStatus":
+	case "GetColumns":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetOperationStatus requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetColumns requires 1 args")
 			flag.Usage()
 		}
 		arg91 := flag.Arg(1)
@@ -475,19 +481,19 @@ func main() {
 		}
 		factory94 := thrift.NewTJSONProtocolFactory()
 		jsProt95 := factory94.GetProtocol(mbTrans92)
-		argvalue0 := cli_service.NewTGetOperationStatusReq()
+		argvalue0 := cli_service.NewTGetColumnsReq()
 		err96 := argvalue0.Read(context.Background(), jsProt95)
 		if err96 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetOperationStatus(context.Background(), value0))
+		fmt.Print(client.GetColumns(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "CancelOperation":
+	case "GetFunctions":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "CancelOperation requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetFunctions requires 1 args")
 			flag.Usage()
 		}
 		arg97 := flag.Arg(1)
@@ -500,19 +506,19 @@ func main() {
 		}
 		factory100 := thrift.NewTJSONProtocolFactory()
 		jsProt101 := factory100.GetProtocol(mbTrans98)
-		argvalue0 := cli_service.NewTCancelOperationReq()
+		argvalue0 := cli_service.NewTGetFunctionsReq()
 		err102 := argvalue0.Read(context.Background(), jsProt101)
 		if err102 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.CancelOperation(context.Background(), value0))
+		fmt.Print(client.GetFunctions(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "CloseOperation":
+	case "GetOperationStatus":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "CloseOperation requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetOperationStatus requires 1 args")
 			flag.Usage()
 		}
 		arg103 := flag.Arg(1)
@@ -525,19 +531,19 @@ func main() {
 		}
 		factory106 := thrift.NewTJSONProtocolFactory()
 		jsProt107 := factory106.GetProtocol(mbTrans104)
-		argvalue0 := cli_service.NewTCloseOperationReq()
+		argvalue0 := cli_service.NewTGetOperationStatusReq()
 		err108 := argvalue0.Read(context.Background(), jsProt107)
 		if err108 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.CloseOperation(context.Background(), value0))
+		fmt.Print(client.GetOperationStatus(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetResultSetMetadata":
+	case "CancelOperation":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetResultSetMetadata requires 1 args")
+			fmt.Fprintln(os.Stderr, "CancelOperation requires 1 args")
 			flag.Usage()
 		}
 		arg109 := flag.Arg(1)
@@ -550,19 +556,19 @@ func main() {
 		}
 		factory112 := thrift.NewTJSONProtocolFactory()
 		jsProt113 := factory112.GetProtocol(mbTrans110)
-		argvalue0 := cli_service.NewTGetResultSetMetadataReq()
+		argvalue0 := cli_service.NewTCancelOperationReq()
 		err114 := argvalue0.Read(context.Background(), jsProt113)
 		if err114 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.GetResultSetMetadata(context.Background(), value0))
+		fmt.Print(client.CancelOperation(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "FetchResults":
+	case "CloseOperation":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "FetchResults requires 1 args")
+			fmt.Fprintln(os.Stderr, "CloseOperation requires 1 args")
 			flag.Usage()
 		}
 		arg115 := flag.Arg(1)
@@ -575,19 +581,19 @@ func main() {
 		}
 		factory118 := thrift.NewTJSONProtocolFactory()
 		jsProt119 := factory118.GetProtocol(mbTrans116)
-		argvalue0 := cli_service.NewTFetchResultsReq()
+		argvalue0 := cli_service.NewTCloseOperationReq()
 		err120 := argvalue0.Read(context.Background(), jsProt119)
 		if err120 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
-		fmt.Print(client.FetchResults(context.Background(), value0))
+		fmt.Print(client.CloseOperation(context.Background(), value0))
 		fmt.Print("\n")
 		break
-	case "GetLog":
+	case "GetResultSetMetadata":
 		if flag.NArg() - 1 != 1 {
-			fmt.Fprintln(os.Stderr, "GetLog requires 1 args")
+			fmt.Fprintln(os.Stderr, "GetResultSetMetadata requires 1 args")
 			flag.Usage()
 		}
 		arg121 := flag.Arg(1)
@@ -600,13 +606,63 @@ func main() {
 		}
 		factory124 := thrift.NewTJSONProtocolFactory()
 		jsProt125 := factory124.GetProtocol(mbTrans122)
-		argvalue0 := cli_service.NewTGetLogReq()
+		argvalue0 := cli_service.NewTGetResultSetMetadataReq()
 		err126 := argvalue0.Read(context.Background(), jsProt125)
 		if err126 != nil {
 			Usage()
 			return
 		}
 		value0 := argvalue0
+		fmt.Print(client.GetResultSetMetadata(context.Background(), value0))
+		fmt.Print("\n")
+		break
+	case "FetchResults":
+		if flag.NArg() - 1 != 1 {
+			fmt.Fprintln(os.Stderr, "FetchResults requires 1 args")
+			flag.Usage()
+		}
+		arg127 := flag.Arg(1)
+		mbTrans128 := thrift.NewTMemoryBufferLen(len(arg127))
+		defer mbTrans128.Close()
+		_, err129 := mbTrans128.WriteString(arg127)
+		if err129 != nil {
+			Usage()
+			return
+		}
+		factory130 := thrift.NewTJSONProtocolFactory()
+		jsProt131 := factory130.GetProtocol(mbTrans128)
+		argvalue0 := cli_service.NewTFetchResultsReq()
+		err132 := argvalue0.Read(context.Background(), jsProt131)
+		if err132 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
+		fmt.Print(client.FetchResults(context.Background(), value0))
+		fmt.Print("\n")
+		break
+	case "GetLog":
+		if flag.NArg() - 1 != 1 {
+			fmt.Fprintln(os.Stderr, "GetLog requires 1 args")
+			flag.Usage()
+		}
+		arg133 := flag.Arg(1)
+		mbTrans134 := thrift.NewTMemoryBufferLen(len(arg133))
+		defer mbTrans134.Close()
+		_, err135 := mbTrans134.WriteString(arg133)
+		if err135 != nil {
+			Usage()
+			return
+		}
+		factory136 := thrift.NewTJSONProtocolFactory()
+		jsProt137 := factory136.GetProtocol(mbTrans134)
+		argvalue0 := cli_service.NewTGetLogReq()
+		err138 := argvalue0.Read(context.Background(), jsProt137)
+		if err138 != nil {
+			Usage()
+			return
+		}
+		value0 := argvalue0
 		fmt.Print(client.GetLog(context.Background(), value0))
 		fmt.Print("\n")
 		break
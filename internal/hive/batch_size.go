@@ -0,0 +1,101 @@
+package hive
+
+import (
+	"time"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+)
+
+// BatchSizing makes result sets adapt the number of rows that they fetch per request to the width of the rows
+// and the round-trip time, instead of always fetching Options.MaxRows rows
+type BatchSizing struct {
+	// MinRows and MaxRows bound the number of rows per fetch
+	MinRows int64
+	MaxRows int64
+	// TargetBytes is the size of the decoded values of a batch that the number of rows is chosen for
+	TargetBytes int64
+	// MaxFetchTime, if not 0, shrinks the batches of fetches that take longer, so that the reader gets
+	// rows steadily and fetches stay well below the socket timeout
+	MaxFetchTime time.Duration
+}
+
+// batchSizer chooses the number of rows of each fetch of a result set
+type batchSizer struct {
+	op     *Operation
+	sizing *BatchSizing
+	// rows is the number of rows of the next fetch
+	rows int64
+}
+
+// newBatchSizer returns the sizer of the result set of op, starting with maxRows rows per fetch,
+// or nil if sizing is nil
+func newBatchSizer(op *Operation, sizing *BatchSizing, maxRows int64) *batchSizer {
+	if sizing == nil {
+		return nil
+	}
+	s := &batchSizer{op: op, sizing: sizing}
+	s.rows = s.clamp(maxRows)
+	op.hive.log.Printf("query %s: adaptive batch size, starting with %d rows (min %d, max %d, target %d bytes)",
+		op.QueryID(), s.rows, sizing.MinRows, sizing.MaxRows, sizing.TargetBytes)
+	return s
+}
+
+// update chooses the number of rows of the next fetch after a fetch returned rows in rtt.
+// The number of rows grows at most 2 times per fetch, so that one batch of narrow rows doesn't
+// make the next batch too big if the rows get wider.
+func (s *batchSizer) update(rows *cli_service.TRowSet, rtt time.Duration) {
+	n := int64(length(rows))
+	if n == 0 {
+		return
+	}
+	bytes := rowSetBytes(rows)
+	next := s.rows
+	if bytes > 0 {
+		next = s.sizing.TargetBytes * n / bytes
+	}
+	if maxTime := s.sizing.MaxFetchTime; maxTime > 0 && rtt > maxTime {
+		next = min(next, int64(float64(n)*float64(maxTime)/float64(rtt)))
+	}
+	next = s.clamp(min(next, 2*s.rows))
+	if next != s.rows {
+		s.op.hive.log.Printf("query %s: fetched %d rows, %d bytes in %v; fetching up to %d rows per batch",
+			s.op.QueryID(), n, bytes, rtt, next)
+		s.rows = next
+	}
+}
+
+func (s *batchSizer) clamp(rows int64) int64 {
+	return max(s.sizing.MinRows, min(rows, s.sizing.MaxRows), 1)
+}
+
+// rowSetBytes returns the size of the decoded values of the row set, including null bitmaps
+func rowSetBytes(rs *cli_service.TRowSet) int64 {
+	var size int
+	for _, col := range rs.Columns {
+		switch {
+		case col.BoolVal != nil:
+			size += len(col.BoolVal.Values) + len(col.BoolVal.Nulls)
+		case col.ByteVal != nil:
+			size += len(col.ByteVal.Values) + len(col.ByteVal.Nulls)
+		case col.I16Val != nil:
+			size += 2*len(col.I16Val.Values) + len(col.I16Val.Nulls)
+		case col.I32Val != nil:
+			size += 4*len(col.I32Val.Values) + len(col.I32Val.Nulls)
+		case col.I64Val != nil:
+			size += 8*len(col.I64Val.Values) + len(col.I64Val.Nulls)
+		case col.DoubleVal != nil:
+			size += 8*len(col.DoubleVal.Values) + len(col.DoubleVal.Nulls)
+		case col.StringVal != nil:
+			for _, v := range col.StringVal.Values {
+				size += len(v)
+			}
+			size += len(col.StringVal.Nulls)
+		case col.BinaryVal != nil:
+			for _, v := range col.BinaryVal.Values {
+				size += len(v)
+			}
+			size += len(col.BinaryVal.Nulls)
+		}
+	}
+	return int64(size)
+}
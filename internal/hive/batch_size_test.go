@@ -0,0 +1,70 @@
+package hive
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSizer(t *testing.T) {
+	var logOut bytes.Buffer
+	op := &Operation{
+		hive: &Client{log: log.New(&logOut, "", 0)},
+		h:    &cli_service.TOperationHandle{OperationId: &cli_service.THandleIdentifier{GUID: make([]byte, 16)}},
+	}
+	sizing := &BatchSizing{MinRows: 10, MaxRows: 1000, TargetBytes: 8000, MaxFetchTime: time.Second}
+	int64Rows := func(n int) *cli_service.TRowSet {
+		return &cli_service.TRowSet{Columns: []*cli_service.TColumn{
+			{I64Val: &cli_service.TI64Column{Values: make([]int64, n), Nulls: make([]byte, (n+7)/8)}},
+		}}
+	}
+	stringRows := func(n int, width int) *cli_service.TRowSet {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = strings.Repeat("x", width)
+		}
+		return &cli_service.TRowSet{Columns: []*cli_service.TColumn{
+			{StringVal: &cli_service.TStringColumn{Values: values, Nulls: make([]byte, (n+7)/8)}},
+		}}
+	}
+
+	require.Nil(t, newBatchSizer(op, nil, 100))
+
+	s := newBatchSizer(op, sizing, 5000)
+	require.EqualValues(t, 1000, s.rows)
+	require.Contains(t, logOut.String(), "adaptive batch size, starting with 1000 rows")
+
+	// 1000 rows of 100 bytes and the null bitmap: 79 rows fit in 8000 bytes
+	s.update(stringRows(1000, 100), time.Millisecond)
+	require.EqualValues(t, 79, s.rows)
+	require.Contains(t, logOut.String(), "fetched 1000 rows, 100125 bytes in 1ms; fetching up to 79 rows per batch")
+
+	// narrow rows grow at most 2 times per fetch
+	s.update(int64Rows(79), time.Millisecond)
+	require.EqualValues(t, 158, s.rows)
+	s.update(int64Rows(158), time.Millisecond)
+	require.EqualValues(t, 316, s.rows)
+
+	// a slow fetch shrinks the batch to what can be fetched in MaxFetchTime
+	s.update(int64Rows(316), 4*time.Second)
+	require.EqualValues(t, 79, s.rows)
+
+	// bounds
+	s.update(stringRows(79, 10000), time.Millisecond)
+	require.EqualValues(t, 10, s.rows)
+	s.rows = 600
+	s.update(&cli_service.TRowSet{Columns: []*cli_service.TColumn{
+		{BoolVal: &cli_service.TBoolColumn{Values: make([]bool, 600), Nulls: make([]byte, 75)}},
+	}}, time.Millisecond)
+	require.EqualValues(t, 1000, s.rows)
+
+	// empty batches are ignored
+	s.update(int64Rows(0), time.Hour)
+	s.update(nil, time.Hour)
+	require.EqualValues(t, 1000, s.rows)
+}
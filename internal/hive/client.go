@@ -2,8 +2,14 @@ package hive
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/sclgo/impala-go/internal/generated/cli_service"
@@ -13,36 +19,86 @@ import (
 // Client represents Hive Client
 type Client struct {
 	client impalaservice.ImpalaHiveServer2Service
-	opts   *Options
-	log    *log.Logger
+	// tclient, if not nil, is the serialized Thrift client under client
+	tclient thrift.TClient
+	opts    *Options
+	log     *log.Logger
 }
 
 // Options for Hive Client
 type Options struct {
 	MaxRows int64
+	// BatchSizing, if not nil, makes result sets adapt the number of rows per fetch, starting from MaxRows
+	BatchSizing *BatchSizing
+	// Prefetch is the number of result batches that a goroutine fetches ahead of the reader of a result set.
+	// 0 means that batches are fetched only when the reader needs them.
+	Prefetch int
 	// MemLimit configures the MEM_LIMIT Impala property at session level
 	// https://impala.apache.org/docs/build/html/topics/impala_mem_limit.html
 	MemLimit string
 	// QueryTimeout in seconds - for QUERY_TIMEOUT_S session configuration value
 	// https://impala.apache.org/docs/build/html/topics/impala_query_timeout_s.html
 	QueryTimeout int
+	// SessionConfig contains query options, sent when opening a session. MemLimit and QueryTimeout
+	// override the MEM_LIMIT and QUERY_TIMEOUT_S entries, if set.
+	SessionConfig map[string]string
+	// StrictSessionConfig makes OpenSession fail if the server drops any SessionConfig entry, and
+	// ExecuteStatement fail if the server doesn't know some statement query option.
+	// Otherwise, dropped entries and unknown options are only logged.
+	StrictSessionConfig bool
+	// Database is the current database of new sessions. Empty means the server default.
+	Database string
 }
 
+// doAsUserKey is the session configuration key that Impala uses for impersonation
+const doAsUserKey = "impala.doas.user"
+
+// ErrSessionConfigDropped means that the server didn't apply some of the session configuration
+var ErrSessionConfigDropped = errors.New("session configuration was not applied by the server")
+
+// ErrUnknownQueryOption means that a statement has query options that the server doesn't know
+var ErrUnknownQueryOption = errors.New("unknown query options")
+
 // NewClient creates Hive Client
 func NewClient(client thrift.TClient, log *log.Logger, opts *Options) *Client {
+	tclient := &syncClient{client: client}
 	return &Client{
-		client: impalaservice.NewImpalaHiveServer2ServiceClient(client),
-		log:    log,
-		opts:   opts,
+		client:  impalaservice.NewImpalaHiveServer2ServiceClient(tclient),
+		tclient: tclient,
+		log:     log,
+		opts:    opts,
 	}
 }
 
-// OpenSession creates new hive session
-func (c *Client) OpenSession(ctx context.Context) (*Session, error) {
+// syncClient serializes the calls to client, so that result sets can prefetch in the background
+// while the connection calls the server for other purposes, e.g. for the query log
+type syncClient struct {
+	mu     sync.Mutex
+	client thrift.TClient
+}
+
+func (c *syncClient) Call(ctx context.Context, method string, args, result thrift.TStruct) (thrift.ResponseMeta, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.Call(ctx, method, args, result)
+}
 
-	cfg := map[string]string{
-		"MEM_LIMIT":       c.opts.MemLimit,
-		"QUERY_TIMEOUT_S": strconv.Itoa(c.opts.QueryTimeout),
+// fetchClient returns a service client for fetching the results of an operation. The generated clients
+// record the metadata of the last response, so result sets that prefetch in the background need their own.
+func (c *Client) fetchClient() impalaservice.ImpalaHiveServer2Service {
+	if c.tclient == nil {
+		return c.client
+	}
+	return impalaservice.NewImpalaHiveServer2ServiceClient(c.tclient)
+}
+
+// OpenSession opens a session. If doAsUser is not empty, the server runs all queries
+// in the session as that user. The connected user must be allowed to impersonate it.
+func (c *Client) OpenSession(ctx context.Context, doAsUser string) (*Session, error) {
+
+	cfg := c.sessionConfig()
+	if doAsUser != "" {
+		cfg[doAsUserKey] = doAsUser
 	}
 
 	req := cli_service.TOpenSessionReq{
@@ -60,5 +116,112 @@ func (c *Client) OpenSession(ctx context.Context) (*Session, error) {
 
 	c.log.Printf("open session: %s", guid(resp.SessionHandle.GetSessionId().GUID))
 	c.log.Printf("session config: %v", resp.Configuration)
-	return &Session{h: resp.SessionHandle, hive: c}, nil
+	session := &Session{h: resp.SessionHandle, hive: c, user: doAsUser, serverConfig: resp.Configuration}
+
+	if err = c.checkSessionConfig(resp.Configuration); err != nil {
+		_ = session.Close(ctx)
+		return nil, err
+	}
+	if err = c.useDatabase(ctx, session); err != nil {
+		_ = session.Close(ctx)
+		return nil, err
+	}
+	return session, nil
+}
+
+// AttachSession returns a session with the given handle, possibly opened by another client.
+// Impala accepts the handle from any connection, authenticated as the same user, to the same coordinator.
+func (c *Client) AttachSession(h *cli_service.TSessionHandle) *Session {
+	return &Session{h: h, hive: c}
+}
+
+// useDatabase makes Database the current database in the session, if set. The use:database key in
+// the session configuration is enough for Hive, but Impala doesn't check if the database exists,
+// so a USE statement is always executed after the session is opened.
+func (c *Client) useDatabase(ctx context.Context, session *Session) error {
+	db := c.opts.Database
+	if db == "" {
+		return nil
+	}
+	if strings.Contains(db, "`") {
+		return fmt.Errorf("%w: invalid database name %s", ErrSessionConfigDropped, db)
+	}
+	err := session.exec(ctx, "USE `"+db+"`")
+	if err == nil {
+		return nil
+	}
+	var transportErr thrift.TTransportException
+	if errors.As(err, &transportErr) {
+		return err // the connection is broken - not a configuration issue
+	}
+	return fmt.Errorf("%w: failed to use database %s: %w", ErrSessionConfigDropped, db, err)
+}
+
+func (c *Client) sessionConfig() map[string]string {
+	cfg := maps.Clone(c.opts.SessionConfig)
+	if cfg == nil {
+		cfg = make(map[string]string)
+	}
+	if c.opts.Database != "" {
+		cfg["use:database"] = c.opts.Database
+	}
+	if _, ok := lookupOption(cfg, "MEM_LIMIT"); !ok || c.opts.MemLimit != "" {
+		setOption(cfg, "MEM_LIMIT", c.opts.MemLimit)
+	}
+	if _, ok := lookupOption(cfg, "QUERY_TIMEOUT_S"); !ok || c.opts.QueryTimeout != 0 {
+		setOption(cfg, "QUERY_TIMEOUT_S", strconv.Itoa(c.opts.QueryTimeout))
+	}
+	return cfg
+}
+
+// checkSessionConfig verifies that the server applied all options in SessionConfig, using the configuration
+// that the server returns. Impala returns all query options, including the defaults, and silently ignores
+// unknown options. Values may be normalized e.g. 1g becomes 1073741824, so only the presence of the option is checked.
+func (c *Client) checkSessionConfig(serverCfg map[string]string) error {
+	if serverCfg == nil {
+		// Older servers, and Hive, do not return the configuration.
+		return nil
+	}
+	var dropped []string
+	for key, val := range c.opts.SessionConfig {
+		serverVal, ok := lookupOption(serverCfg, key)
+		if !ok {
+			dropped = append(dropped, key)
+		} else if serverVal != val {
+			c.log.Printf("session option %s=%s was applied as %s", key, val, serverVal)
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+	slices.Sort(dropped)
+	err := fmt.Errorf("%w: %s", ErrSessionConfigDropped, strings.Join(dropped, ", "))
+	if c.opts.StrictSessionConfig {
+		return err
+	}
+	c.log.Println(err)
+	return nil
+}
+
+// lookupOption finds a query option by name. Impala option names are case-insensitive.
+func lookupOption(cfg map[string]string, name string) (string, bool) {
+	if val, ok := cfg[name]; ok {
+		return val, true
+	}
+	for key, val := range cfg {
+		if strings.EqualFold(key, name) {
+			return val, true
+		}
+	}
+	return "", false
+}
+
+// setOption sets a query option, replacing any entry with the same name in a different case
+func setOption(cfg map[string]string, name string, val string) {
+	for key := range cfg {
+		if strings.EqualFold(key, name) {
+			delete(cfg, key)
+		}
+	}
+	cfg[name] = val
 }
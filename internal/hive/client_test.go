@@ -0,0 +1,192 @@
+package hive
+
+import (
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_OpenSession(t *testing.T) {
+	serverCfg := map[string]string{
+		"MEM_LIMIT":       "0",
+		"QUERY_TIMEOUT_S": "0",
+		"REQUEST_POOL":    "etl",
+		"EXPLAIN_LEVEL":   "2",
+	}
+
+	t.Run("config sent", func(t *testing.T) {
+		mock := &sessionThriftClient{serverCfg: serverCfg}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{
+			MemLimit:      "1g",
+			SessionConfig: map[string]string{"request_pool": "etl", "mem_limit": "2g", "EXPLAIN_LEVEL": "verbose"},
+		}}
+		_, err := client.OpenSession(context.Background(), "")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"request_pool":    "etl",
+			"MEM_LIMIT":       "1g",
+			"QUERY_TIMEOUT_S": "0",
+			"EXPLAIN_LEVEL":   "verbose",
+		}, mock.req.Configuration)
+	})
+
+	t.Run("dropped option logged", func(t *testing.T) {
+		mock := &sessionThriftClient{serverCfg: serverCfg}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{
+			SessionConfig: map[string]string{"NO_SUCH_OPTION": "1"},
+		}}
+		_, err := client.OpenSession(context.Background(), "")
+		require.NoError(t, err)
+		require.False(t, mock.closed)
+	})
+
+	t.Run("dropped option rejected", func(t *testing.T) {
+		mock := &sessionThriftClient{serverCfg: serverCfg}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{
+			SessionConfig:       map[string]string{"NO_SUCH_OPTION": "1", "REQUEST_POOL": "etl"},
+			StrictSessionConfig: true,
+		}}
+		_, err := client.OpenSession(context.Background(), "")
+		require.ErrorIs(t, err, ErrSessionConfigDropped)
+		require.ErrorContains(t, err, "NO_SUCH_OPTION")
+		require.NotContains(t, err.Error(), "REQUEST_POOL")
+		require.True(t, mock.closed)
+	})
+
+	t.Run("impersonation", func(t *testing.T) {
+		mock := &sessionThriftClient{}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{}}
+		session, err := client.OpenSession(context.Background(), "alice")
+		require.NoError(t, err)
+		require.Equal(t, "alice", mock.req.Configuration["impala.doas.user"])
+		require.Equal(t, "alice", session.ImpersonatedUser())
+	})
+
+	t.Run("database", func(t *testing.T) {
+		mock := &sessionThriftClient{serverCfg: serverCfg}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{Database: "sales"}}
+		_, err := client.OpenSession(context.Background(), "")
+		require.NoError(t, err)
+		require.Equal(t, "sales", mock.req.Configuration["use:database"])
+		// Impala returns the configuration but doesn't check if the database exists
+		require.Equal(t, []string{"USE `sales`"}, mock.statements)
+		require.False(t, mock.closed)
+	})
+
+	t.Run("database missing", func(t *testing.T) {
+		mock := &sessionThriftClient{}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{Database: "missing"}}
+		_, err := client.OpenSession(context.Background(), "")
+		require.ErrorIs(t, err, ErrSessionConfigDropped)
+		require.ErrorContains(t, err, "Database does not exist: missing")
+		require.True(t, mock.closed)
+	})
+}
+
+func TestSession_ExecuteStatement(t *testing.T) {
+	serverCfg := map[string]string{"MT_DOP": "0", "REQUEST_POOL": ""}
+	open := func(t *testing.T, strict bool) (*Session, *sessionThriftClient) {
+		mock := &sessionThriftClient{serverCfg: serverCfg}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{StrictSessionConfig: strict}}
+		session, err := client.OpenSession(context.Background(), "")
+		require.NoError(t, err)
+		return session, mock
+	}
+
+	t.Run("query options sent", func(t *testing.T) {
+		session, mock := open(t, true)
+		options := map[string]string{"mt_dop": "4", "REQUEST_POOL": "etl"}
+		_, err := session.ExecuteStatement(context.Background(), "SELECT 1", options)
+		require.NoError(t, err)
+		require.Equal(t, []map[string]string{options}, mock.overlays)
+	})
+
+	t.Run("unknown option logged", func(t *testing.T) {
+		session, mock := open(t, false)
+		_, err := session.ExecuteStatement(context.Background(), "SELECT 1", map[string]string{"NO_SUCH_OPTION": "1"})
+		require.NoError(t, err)
+		require.Len(t, mock.overlays, 1)
+	})
+
+	t.Run("unknown option rejected", func(t *testing.T) {
+		session, mock := open(t, true)
+		_, err := session.ExecuteStatement(context.Background(), "SELECT 1", map[string]string{"NO_SUCH_OPTION": "1", "MT_DOP": "2"})
+		require.ErrorIs(t, err, ErrUnknownQueryOption)
+		require.ErrorContains(t, err, "NO_SUCH_OPTION")
+		require.NotContains(t, err.Error(), "MT_DOP")
+		require.Empty(t, mock.statements)
+	})
+
+	t.Run("server options unknown", func(t *testing.T) {
+		mock := &sessionThriftClient{}
+		client := &Client{client: mock, log: log.Default(), opts: &Options{StrictSessionConfig: true}}
+		session := client.AttachSession(&cli_service.TSessionHandle{})
+		_, err := session.ExecuteStatement(context.Background(), "SELECT 1", map[string]string{"NO_SUCH_OPTION": "1"})
+		require.NoError(t, err)
+	})
+}
+
+type sessionThriftClient struct {
+	impalaservice.ImpalaHiveServer2Service
+
+	serverCfg  map[string]string
+	req        *cli_service.TOpenSessionReq
+	statements []string
+	overlays   []map[string]string
+	closed     bool
+}
+
+func (m *sessionThriftClient) OpenSession(_ context.Context, req *cli_service.TOpenSessionReq) (*cli_service.TOpenSessionResp, error) {
+	m.req = req
+	sessionID := uuid.New()
+	return &cli_service.TOpenSessionResp{
+		Status:        &cli_service.TStatus{StatusCode: cli_service.TStatusCode_SUCCESS_STATUS},
+		SessionHandle: &cli_service.TSessionHandle{SessionId: &cli_service.THandleIdentifier{GUID: sessionID[:]}},
+		Configuration: m.serverCfg,
+	}, nil
+}
+
+func (m *sessionThriftClient) CloseSession(context.Context, *cli_service.TCloseSessionReq) (*cli_service.TCloseSessionResp, error) {
+	m.closed = true
+	return &cli_service.TCloseSessionResp{
+		Status: &cli_service.TStatus{StatusCode: cli_service.TStatusCode_SUCCESS_STATUS},
+	}, nil
+}
+
+func (m *sessionThriftClient) ExecuteStatement(_ context.Context, req *cli_service.TExecuteStatementReq) (*cli_service.TExecuteStatementResp, error) {
+	m.statements = append(m.statements, req.Statement)
+	if req.ConfOverlay != nil {
+		m.overlays = append(m.overlays, req.ConfOverlay)
+	}
+	if strings.Contains(req.Statement, "missing") {
+		return &cli_service.TExecuteStatementResp{Status: &cli_service.TStatus{
+			StatusCode:   cli_service.TStatusCode_ERROR_STATUS,
+			ErrorMessage: lo.ToPtr("AnalysisException: Database does not exist: missing"),
+		}}, nil
+	}
+	opID := uuid.New()
+	return &cli_service.TExecuteStatementResp{
+		Status:          &cli_service.TStatus{StatusCode: cli_service.TStatusCode_SUCCESS_STATUS},
+		OperationHandle: &cli_service.TOperationHandle{OperationId: &cli_service.THandleIdentifier{GUID: opID[:]}},
+	}, nil
+}
+
+func (m *sessionThriftClient) GetOperationStatus(context.Context, *cli_service.TGetOperationStatusReq) (*cli_service.TGetOperationStatusResp, error) {
+	return &cli_service.TGetOperationStatusResp{
+		Status:         &cli_service.TStatus{StatusCode: cli_service.TStatusCode_SUCCESS_STATUS},
+		OperationState: lo.ToPtr(cli_service.TOperationState_FINISHED_STATE),
+	}, nil
+}
+
+func (m *sessionThriftClient) CloseImpalaOperation(context.Context, *impalaservice.TCloseImpalaOperationReq) (*impalaservice.TCloseImpalaOperationResp, error) {
+	return &impalaservice.TCloseImpalaOperationResp{
+		Status: &cli_service.TStatus{StatusCode: cli_service.TStatusCode_SUCCESS_STATUS},
+	}, nil
+}
@@ -0,0 +1,104 @@
+package hive
+
+import (
+	"io"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+)
+
+// Vector is a column of a Batch with values of type T
+type Vector[T any] struct {
+	// Values has a value for each row. The values of NULLs are zero values.
+	Values []T
+	// Nulls is the null bitmap: the value at index i is NULL if bit i%8 of Nulls[i/8] is set.
+	// Bits beyond the end of Nulls are not set.
+	Nulls []byte
+}
+
+// Len returns the number of values
+func (v Vector[T]) Len() int {
+	return len(v.Values)
+}
+
+// IsNull tells if the value at index i is NULL
+func (v Vector[T]) IsNull(i int) bool {
+	return i/8 < len(v.Nulls) && isSet(v.Nulls, i)
+}
+
+// Batch is a batch of rows of a result set in columnar form
+type Batch struct {
+	// Len is the number of rows
+	Len int
+	// Names are the names of the columns
+	Names []string
+	// Columns has a vector of each column, depending on how the server sent the column:
+	// Vector[bool] for BOOLEAN, Vector[int8] for TINYINT, Vector[int16] for SMALLINT, Vector[int32] for INT,
+	// Vector[int64] for BIGINT, Vector[float64] for FLOAT and DOUBLE, Vector[[]byte] for binary columns,
+	// and Vector[string] for other types, e.g. TIMESTAMP values in TimestampFormat and DECIMAL values as text.
+	Columns []any
+}
+
+// NextBatch returns the rows that Next has not returned yet from the last fetched batch, fetching the next
+// batch if none remain. It returns io.EOF after the last row. The vectors share memory with the fetched batch.
+func (rs *ResultSet) NextBatch() (*Batch, error) {
+	if err := rs.fill(); err != nil {
+		return nil, err
+	}
+	if rs.idx >= rs.length {
+		return nil, io.EOF
+	}
+	if rs.names == nil {
+		rs.names = make([]string, len(rs.schema.Columns))
+		for i, cd := range rs.schema.Columns {
+			rs.names[i] = cd.Name
+		}
+	}
+	batch := &Batch{Len: rs.length - rs.idx, Names: rs.names, Columns: make([]any, len(rs.result.Columns))}
+	for i, col := range rs.result.Columns {
+		batch.Columns[i] = vector(col, rs.idx)
+	}
+	rs.idx = rs.length
+	return batch, nil
+}
+
+// vector returns the values of col from index from on
+func vector(col *cli_service.TColumn, from int) any {
+	switch {
+	case col.BoolVal != nil:
+		return newVector(col.BoolVal.Values, col.BoolVal.Nulls, from)
+	case col.ByteVal != nil:
+		return newVector(col.ByteVal.Values, col.ByteVal.Nulls, from)
+	case col.I16Val != nil:
+		return newVector(col.I16Val.Values, col.I16Val.Nulls, from)
+	case col.I32Val != nil:
+		return newVector(col.I32Val.Values, col.I32Val.Nulls, from)
+	case col.I64Val != nil:
+		return newVector(col.I64Val.Values, col.I64Val.Nulls, from)
+	case col.DoubleVal != nil:
+		return newVector(col.DoubleVal.Values, col.DoubleVal.Nulls, from)
+	case col.BinaryVal != nil:
+		return newVector(col.BinaryVal.Values, col.BinaryVal.Nulls, from)
+	case col.StringVal != nil:
+		return newVector(col.StringVal.Values, col.StringVal.Nulls, from)
+	}
+	return nil
+}
+
+func newVector[T any](values []T, nulls []byte, from int) Vector[T] {
+	return Vector[T]{Values: values[from:], Nulls: shiftBitmap(nulls, from, len(values)-from)}
+}
+
+// shiftBitmap returns the n bits of bitmap from index from on as a bitmap. The bitmap is reused
+// if from is at a byte boundary.
+func shiftBitmap(bitmap []byte, from int, n int) []byte {
+	if from%8 == 0 {
+		return bitmap[min(from/8, len(bitmap)):]
+	}
+	shifted := make([]byte, (n+7)/8)
+	for i := 0; i < n && (from+i)/8 < len(bitmap); i++ {
+		if isSet(bitmap, from+i) {
+			shifted[i/8] |= 1 << (uint(i) % 8)
+		}
+	}
+	return shifted
+}
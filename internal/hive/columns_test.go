@@ -0,0 +1,107 @@
+package hive
+
+import (
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextBatch(t *testing.T) {
+	r := &results{data: []any{
+		[]*cli_service.TColumn{
+			// rows 1 and 9 are NULL
+			{I64Val: &cli_service.TI64Column{Values: []int64{0, 0, 2, 3, 4, 5, 6, 7, 8, 0}, Nulls: []byte{0b10, 0b10}}},
+			{StringVal: &cli_service.TStringColumn{Values: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, Nulls: []byte{0, 0}}},
+		},
+		[]*cli_service.TColumn{
+			{I64Val: &cli_service.TI64Column{Values: []int64{10}, Nulls: []byte{0}}},
+			{StringVal: &cli_service.TStringColumn{Values: []string{"k"}, Nulls: []byte{1}}},
+		},
+	}}
+	rs := ResultSet{
+		fetchfn: r.fetch,
+		more:    true,
+		schema:  &TableSchema{Columns: []*ColDesc{{DatabaseTypeName: "BIGINT"}, {DatabaseTypeName: "STRING"}}},
+	}
+	dest := make([]driver.Value, 2)
+	for range 3 {
+		require.NoError(t, rs.Next(dest))
+	}
+
+	batch, err := rs.NextBatch()
+	require.NoError(t, err)
+	require.Equal(t, 7, batch.Len)
+	ints := batch.Columns[0].(Vector[int64])
+	require.Equal(t, []int64{3, 4, 5, 6, 7, 8, 0}, ints.Values)
+	for i := range ints.Len() {
+		require.Equal(t, i == 6, ints.IsNull(i), i)
+	}
+	strs := batch.Columns[1].(Vector[string])
+	require.Equal(t, []string{"d", "e", "f", "g", "h", "i", "j"}, strs.Values)
+	require.False(t, strs.IsNull(0))
+
+	batch, err = rs.NextBatch()
+	require.NoError(t, err)
+	require.Equal(t, 1, batch.Len)
+	require.True(t, batch.Columns[1].(Vector[string]).IsNull(0))
+	require.False(t, batch.Columns[0].(Vector[int64]).IsNull(0))
+
+	_, err = rs.NextBatch()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestShiftBitmap(t *testing.T) {
+	bitmap := []byte{0b10110001, 0b00000011}
+	require.Equal(t, []byte{0b00000011}, shiftBitmap(bitmap, 8, 2))
+	require.Equal(t, []byte{0b11011000, 0b1}, shiftBitmap(bitmap, 1, 9))
+	require.Equal(t, []byte{0b111}, shiftBitmap(bitmap, 7, 3))
+	require.Equal(t, []byte{0b10110}, shiftBitmap(bitmap[:1], 3, 7))
+	require.Empty(t, shiftBitmap(nil, 16, 3))
+}
+
+// BenchmarkResultSet compares reading a batch of rows row by row and in columnar form
+func BenchmarkResultSet(b *testing.B) {
+	const n = 1024
+	ints := make([]int64, n)
+	strs := make([]string, n)
+	for i := range n {
+		ints[i] = int64(i)
+		strs[i] = "value"
+	}
+	columns := []*cli_service.TColumn{
+		{I64Val: &cli_service.TI64Column{Values: ints, Nulls: make([]byte, n/8)}},
+		{I32Val: &cli_service.TI32Column{Values: make([]int32, n), Nulls: make([]byte, n/8)}},
+		{StringVal: &cli_service.TStringColumn{Values: strs, Nulls: make([]byte, n/8)}},
+		{DoubleVal: &cli_service.TDoubleColumn{Values: make([]float64, n), Nulls: make([]byte, n/8)}},
+	}
+	schema := &TableSchema{Columns: []*ColDesc{
+		{DatabaseTypeName: "BIGINT"}, {DatabaseTypeName: "INT"}, {DatabaseTypeName: "STRING"}, {DatabaseTypeName: "DOUBLE"},
+	}}
+	newResultSet := func() *ResultSet {
+		r := &results{data: []any{columns}}
+		return &ResultSet{fetchfn: r.fetch, more: true, schema: schema}
+	}
+
+	b.Run("rows", func(b *testing.B) {
+		dest := make([]driver.Value, len(columns))
+		for range b.N {
+			rs := newResultSet()
+			for rs.Next(dest) == nil {
+			}
+		}
+	})
+	b.Run("columns", func(b *testing.B) {
+		for range b.N {
+			rs := newResultSet()
+			var sum int64
+			for batch, err := rs.NextBatch(); err == nil; batch, err = rs.NextBatch() {
+				for _, v := range batch.Columns[0].(Vector[int64]).Values {
+					sum += v
+				}
+			}
+		}
+	})
+}
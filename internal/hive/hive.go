@@ -1,8 +1,11 @@
 package hive
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/sclgo/impala-go/internal/generated/cli_service"
 )
@@ -74,3 +77,24 @@ func wrapServerError(err error) error {
 func guid(b []byte) string {
 	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
 }
+
+// QueryID formats an operation GUID the way Impala prints query IDs. Impala stores the hi and lo
+// halves of the query ID in the GUID as little-endian integers.
+func QueryID(b []byte) string {
+	if len(b) != 16 {
+		return ""
+	}
+	return fmt.Sprintf("%016x:%016x", binary.LittleEndian.Uint64(b[0:8]), binary.LittleEndian.Uint64(b[8:16]))
+}
+
+// parseQueryID is the inverse of queryID
+func parseQueryID(id string) ([]byte, error) {
+	hiStr, loStr, _ := strings.Cut(id, ":")
+	hi, errHi := strconv.ParseUint(hiStr, 16, 64)
+	lo, errLo := strconv.ParseUint(loStr, 16, 64)
+	if errHi != nil || errLo != nil || len(hiStr) != 16 || len(loStr) != 16 {
+		return nil, fmt.Errorf("invalid query ID %q: expected format is 16 hex digits, colon, 16 hex digits", id)
+	}
+	guid := binary.LittleEndian.AppendUint64(make([]byte, 0, 16), hi)
+	return binary.LittleEndian.AppendUint64(guid, lo), nil
+}
@@ -18,6 +18,8 @@ type ColDesc struct {
 
 	DatabaseTypeName string
 	ScanType         reflect.Type
+	// TypeID is the type of the column in the result set metadata
+	TypeID cli_service.TTypeId
 
 	// Impala columns are always Nullable, except some Kudu columns
 	NotNull bool
@@ -57,7 +59,7 @@ func typeOf(entry *cli_service.TPrimitiveTypeEntry) reflect.Type {
 	case cli_service.TTypeId_BIGINT_TYPE:
 		return dataTypeInt64
 	case cli_service.TTypeId_FLOAT_TYPE, cli_service.TTypeId_DOUBLE_TYPE:
-		// see comment in internal/hive/result_set.go#value()
+		// see comment in internal/hive/result_set.go#decoderFor()
 		return dataTypeFloat64
 	case cli_service.TTypeId_NULL_TYPE:
 		return dataTypeNull
@@ -2,12 +2,17 @@ package hive
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/samber/lo"
 	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/generated/execstats"
 	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
 )
 
 const (
@@ -19,8 +24,22 @@ const (
 type Operation struct {
 	hive *Client
 	h    *cli_service.TOperationHandle
+	// session is the handle of the session that the operation was started or attached in
+	session *cli_service.TSessionHandle
+	closed  bool
+	// progress, if not nil, is called after each poll of the operation state
+	progress ProgressFunc
+	// mu guards infoMessages, which a goroutine that prefetches results also appends to
+	mu sync.Mutex
+	// infoMessages are the info messages of the successful responses for the operation
+	infoMessages []string
+	// dmlResult is the result of a DML statement, returned when the operation was closed
+	dmlResult *impalaservice.TDmlResult_
 }
 
+// ProgressFunc receives the exec summary of a query each time the driver polls the query while waiting for it
+type ProgressFunc func(ctx context.Context, queryID string, summary *execstats.TExecSummary)
+
 // HasResultSet return if operation has result set
 func (op *Operation) HasResultSet() bool {
 	return op.h.GetHasResultSet()
@@ -42,7 +61,7 @@ func (op *Operation) GetResultSetMetadata(ctx context.Context) (*TableSchema, er
 	if err != nil {
 		return nil, err
 	}
-	if err := checkStatus(resp); err != nil {
+	if err := op.checkStatus(resp); err != nil {
 		return nil, err
 	}
 
@@ -61,6 +80,7 @@ func (op *Operation) GetResultSetMetadata(ctx context.Context) (*TableSchema, er
 			schema.Columns = append(schema.Columns, &ColDesc{
 				Name:              desc.ColumnName,
 				DatabaseTypeName:  dbtype,
+				TypeID:            entry.Type,
 				ScanType:          typeOf(entry),
 				HasLength:         hasLength,
 				Length:            maxLength,
@@ -87,8 +107,34 @@ func (op *Operation) FetchResults(ctx context.Context, schema *TableSchema) (*Re
 		result: nil,
 		more:   true,
 		schema: schema,
-		// TODO align query context handling with database/sql practices (Github #14)
-		fetchfn: func() (*cli_service.TFetchResultsResp, error) { return fetch(ctx, op) },
+		// the prefetching goroutine uses ctx, like fetchfn
+		prefetch: op.hive.opts.Prefetch,
+		ctx:      ctx,
+	}
+	client := op.hive.fetchClient()
+	sizer := newBatchSizer(op, op.hive.opts.BatchSizing, op.hive.opts.MaxRows)
+	onPoll := func() { op.reportProgress(ctx) }
+	if rs.prefetch > 0 {
+		rs.polls = make(chan struct{}, 1)
+		rs.progressfn = onPoll
+		onPoll = func() {
+			select {
+			case rs.polls <- struct{}{}:
+			default: // a poll is already pending
+			}
+		}
+	}
+	// TODO align query context handling with database/sql practices (Github #14)
+	rs.fetchfn = func() (*cli_service.TFetchResultsResp, error) {
+		if sizer == nil {
+			resp, _, err := fetch(ctx, op, client, op.hive.opts.MaxRows, onPoll)
+			return resp, err
+		}
+		resp, rtt, err := fetch(ctx, op, client, sizer.rows, onPoll)
+		if err == nil {
+			sizer.update(resp.Results, rtt)
+		}
+		return resp, err
 	}
 	return &rs, nil
 }
@@ -102,7 +148,7 @@ func (op *Operation) CheckStateAndStatus(ctx context.Context) (cli_service.TOper
 	if err != nil {
 		return 0, err
 	}
-	if err = checkStatus(resp); err != nil {
+	if err = op.checkStatus(resp); err != nil {
 		return 0, err
 	}
 	if err = checkState(resp); err != nil {
@@ -113,30 +159,201 @@ func (op *Operation) CheckStateAndStatus(ctx context.Context) (cli_service.TOper
 	return state, nil
 }
 
+// Handle returns the operation handle, which identifies the operation on the server
+func (op *Operation) Handle() *cli_service.TOperationHandle {
+	return op.h
+}
+
+// QueryID returns the Impala query ID of the operation in the hi:lo format that Impala shows
+func (op *Operation) QueryID() string {
+	return QueryID(op.h.GetOperationId().GetGUID())
+}
+
+// RuntimeProfile returns the runtime profile of the operation. Depending on format, either the profile text
+// or the profile tree is returned. The profile is available before and after the operation is closed.
+func (op *Operation) RuntimeProfile(ctx context.Context, format runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error) {
+	req := impalaservice.TGetRuntimeProfileReq{
+		OperationHandle: op.h,
+		SessionHandle:   op.session,
+		Format:          format,
+	}
+	resp, err := op.hive.client.GetRuntimeProfile(ctx, &req)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = op.checkStatus(resp); err != nil {
+		return "", nil, err
+	}
+	op.hive.log.Printf("runtime profile of query %s in format %v", op.QueryID(), format)
+	return resp.GetProfile(), resp.GetThriftProfile(), nil
+}
+
+// Log returns the operation log. For Impala, it has the warnings and errors of the query,
+// such as missing table statistics or skipped corrupt files. The log isn't available after the operation is closed.
+func (op *Operation) Log(ctx context.Context) (string, error) {
+	req := cli_service.TGetLogReq{
+		OperationHandle: op.h,
+	}
+	resp, err := op.hive.client.GetLog(ctx, &req)
+	if err != nil {
+		return "", err
+	}
+	if err = op.checkStatus(resp); err != nil {
+		return "", err
+	}
+	return resp.GetLog(), nil
+}
+
+// InfoMessages returns the info messages that the server sent with the responses for the operation so far
+func (op *Operation) InfoMessages() []string {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return append([]string(nil), op.infoMessages...)
+}
+
+// checkStatus checks the status of a response for the operation and keeps its info messages
+func (op *Operation) checkStatus(resp rpcResponse) error {
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	op.keepInfoMessages(resp.GetStatus())
+	return nil
+}
+
+func (op *Operation) keepInfoMessages(status *cli_service.TStatus) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	for _, msg := range status.GetInfoMessages() {
+		op.hive.log.Printf("info message for query %s: %s", op.QueryID(), msg)
+		op.infoMessages = append(op.infoMessages, msg)
+	}
+}
+
+// ExecSummary returns the exec summary of the operation, with the query state, progress and plan node statistics.
+// The summary is complete only for queries that execute in the Impala backend, such as SELECT and DML statements.
+func (op *Operation) ExecSummary(ctx context.Context) (*execstats.TExecSummary, error) {
+	req := impalaservice.TGetExecSummaryReq{
+		OperationHandle: op.h,
+		SessionHandle:   op.session,
+	}
+	resp, err := op.hive.client.GetExecSummary(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	if err = op.checkStatus(resp); err != nil {
+		return nil, err
+	}
+	return resp.GetSummary(), nil
+}
+
+// SetProgressFunc makes WaitToFinish and the result fetching of the operation report progress to fn
+// after each poll of the operation state. nil disables progress reporting.
+func (op *Operation) SetProgressFunc(fn ProgressFunc) {
+	op.progress = fn
+}
+
+// reportProgress passes the exec summary to the progress function, if any. Failing to get the summary
+// doesn't fail the operation so the error is only logged.
+func (op *Operation) reportProgress(ctx context.Context) {
+	if op.progress == nil || ctx.Err() != nil {
+		return
+	}
+	summary, err := op.ExecSummary(ctx)
+	if err == nil && summary == nil {
+		err = errors.New("the server returned no summary")
+	}
+	if err != nil {
+		op.hive.log.Printf("failed to get the exec summary of query %s: %v", op.QueryID(), err)
+		return
+	}
+	op.progress(ctx, op.QueryID(), summary)
+}
+
+// Status returns the operation state and, if the operation failed, the error message reported by the server.
+// Unlike CheckStateAndStatus, a failed or cancelled operation is not an error.
+func (op *Operation) Status(ctx context.Context) (cli_service.TOperationState, string, error) {
+	req := cli_service.TGetOperationStatusReq{
+		OperationHandle: op.h,
+	}
+	resp, err := op.hive.client.GetOperationStatus(ctx, &req)
+	if err != nil {
+		return 0, "", err
+	}
+	if err = op.checkStatus(resp); err != nil {
+		return 0, "", err
+	}
+	return resp.GetOperationState(), resp.GetErrorMessage(), nil
+}
+
+// Cancel cancels the operation on the server. The operation must still be closed after that.
+func (op *Operation) Cancel(ctx context.Context) error {
+	req := cli_service.TCancelOperationReq{
+		OperationHandle: op.h,
+	}
+	resp, err := op.hive.client.CancelOperation(ctx, &req)
+	if err != nil {
+		return err
+	}
+	if err = op.checkStatus(resp); err != nil {
+		return err
+	}
+	op.hive.log.Printf("cancel operation: %v", guid(op.h.OperationId.GUID))
+	return nil
+}
+
+// Abort cancels and closes the operation after ctx is done, using a fallback context.
+// The returned error wraps cause and tells whether the server confirmed the cancellation.
+func (op *Operation) Abort(ctx context.Context, cause error) error {
+	if op.closed {
+		return cause
+	}
+	var cancelErr error
+	_ = withFallbackCtx(ctx, func(ctx context.Context) error {
+		cancelErr = op.Cancel(ctx)
+		_, closeErr := op.Close(ctx)
+		return closeErr
+	})
+	if cancelErr != nil {
+		err := fmt.Errorf("%w; failed to cancel the query on the server: %v", cause, cancelErr)
+		op.hive.log.Println(err)
+		return err
+	}
+	return fmt.Errorf("%w; the server confirmed the query was cancelled", cause)
+}
+
 // WaitToFinish waits for the operation to reach a FINISHED state
 // Returns error if the operation fails or the context is cancelled.
 func (op *Operation) WaitToFinish(ctx context.Context) error {
 	duration := initialBackoff
 	opState, err := op.CheckStateAndStatus(ctx)
+	if err == nil {
+		op.reportProgress(ctx)
+	}
 	for err == nil && opState != cli_service.TOperationState_FINISHED_STATE {
 		sleep(ctx, duration)
 		opState, err = op.CheckStateAndStatus(ctx)
 		// It is important to check ctx.Err() as Thrift almost always ignores context - at least up to v0.21.
 		err = lo.CoalesceOrEmpty(err, ctx.Err())
+		if err == nil {
+			op.reportProgress(ctx)
+		}
 		duration = nextDuration(duration)
 	}
 	return err
 }
 
-func fetch(ctx context.Context, op *Operation) (*cli_service.TFetchResultsResp, error) {
+// fetch fetches up to maxRows rows of the operation, calling onPoll each time the query is still executing.
+// It also returns the round-trip time of the request that returned them, without the time that the query
+// was still executing.
+func fetch(ctx context.Context, op *Operation, client impalaservice.ImpalaHiveServer2Service, maxRows int64, onPoll func()) (*cli_service.TFetchResultsResp, time.Duration, error) {
 	req := cli_service.TFetchResultsReq{
 		OperationHandle: op.h,
-		MaxRows:         op.hive.opts.MaxRows,
+		MaxRows:         maxRows,
 	}
 
 	op.hive.log.Printf("fetch results for operation: %v", guid(op.h.OperationId.GUID))
 
-	var duration time.Duration
+	var duration, rtt time.Duration
 	fetchStatus := cli_service.TStatusCode_STILL_EXECUTING_STATUS
 	resp := &cli_service.TFetchResultsResp{}
 	// It is important to check ctx.Err() as Thrift almost always ignores context - at least up to v0.21.
@@ -150,18 +367,23 @@ func fetch(ctx context.Context, op *Operation) (*cli_service.TFetchResultsResp,
 			duration = nextDuration(duration)
 		}
 		var err error
-		resp, err = op.hive.client.FetchResults(ctx, &req)
+		start := time.Now()
+		resp, err = client.FetchResults(ctx, &req)
+		rtt = time.Since(start)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
-		if err = checkStatus(resp); err != nil {
-			return nil, err
+		if err = op.checkStatus(resp); err != nil {
+			return nil, 0, err
 		}
 		fetchStatus = resp.GetStatus().StatusCode
+		if fetchStatus == cli_service.TStatusCode_STILL_EXECUTING_STATUS {
+			onPoll()
+		}
 	}
 
 	op.hive.log.Printf("results: %v", resp.Results)
-	return resp, ctx.Err()
+	return resp, rtt, ctx.Err()
 }
 
 func nextDuration(duration time.Duration) time.Duration {
@@ -172,8 +394,11 @@ func nextDuration(duration time.Duration) time.Duration {
 	return duration
 }
 
-// Close closes operation and returns rows affected if any
+// Close closes operation and returns rows affected if any. Closing a closed operation does nothing.
 func (op *Operation) Close(ctx context.Context) (int64, error) {
+	if op.closed {
+		return 0, nil
+	}
 	req := impalaservice.TCloseImpalaOperationReq{
 		OperationHandle: op.h,
 	}
@@ -181,14 +406,22 @@ func (op *Operation) Close(ctx context.Context) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if err := checkStatus(resp); err != nil {
+	if err := op.checkStatus(resp); err != nil {
 		return 0, err
 	}
 
+	op.closed = true
+	op.dmlResult = resp.DmlResult_
 	op.hive.log.Printf("close operation: %v", guid(op.h.OperationId.GUID))
 	return calcRowsAffected(resp), nil
 }
 
+// DMLResult returns the detailed result of a DML statement, with row counts per partition and row errors.
+// It is available after the operation is closed and is nil for statements other than DML.
+func (op *Operation) DMLResult() *impalaservice.TDmlResult_ {
+	return op.dmlResult
+}
+
 func calcRowsAffected(resp *impalaservice.TCloseImpalaOperationResp) int64 {
 	if resp.DmlResult_ == nil {
 		return 0
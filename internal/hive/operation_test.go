@@ -29,6 +29,24 @@ func TestOperation(t *testing.T) {
 		require.ErrorIs(t, err, context.Canceled)
 		require.True(t, mock.called)
 	})
+
+	t.Run("query id", func(t *testing.T) {
+		guid := []byte{0xef, 0xcd, 0xab, 0x89, 0x67, 0x45, 0x23, 0x01, 0x10, 0x32, 0x54, 0x76, 0x98, 0xba, 0xdc, 0xfe}
+		op := &Operation{
+			hive: hive,
+			h:    &cli_service.TOperationHandle{OperationId: &cli_service.THandleIdentifier{GUID: guid}},
+		}
+		require.Equal(t, "0123456789abcdef:fedcba9876543210", op.QueryID())
+
+		parsed, err := parseQueryID(op.QueryID())
+		require.NoError(t, err)
+		require.Equal(t, guid, parsed)
+
+		for _, invalid := range []string{"", "0123456789abcdef", "0123456789abcdef:fedcba987654321", "0123456789abcdeg:fedcba9876543210"} {
+			_, err = parseQueryID(invalid)
+			require.ErrorContains(t, err, "invalid query ID")
+		}
+	})
 }
 
 type opThriftClient struct {
@@ -1,7 +1,9 @@
 package hive
 
 import (
+	"context"
 	"database/sql/driver"
+	"errors"
 	"io"
 	"time"
 
@@ -17,14 +19,66 @@ type ResultSet struct {
 
 	result *cli_service.TRowSet
 	more   bool
+
+	// prefetch is the number of batches that a goroutine fetches ahead of Next, or 0 to fetch in Next.
+	// The goroutine starts with the first call of Next and ends when the last batch is fetched,
+	// fetching fails, ctx is done or Close is called.
+	prefetch int
+	ctx      context.Context
+	batches  chan fetchResult
+	stop     chan struct{}
+	// polls tells that the prefetching goroutine polled the still executing query. Next reports the progress
+	// with progressfn while it waits for batches, because the progress function is not called from other goroutines.
+	polls      chan struct{}
+	progressfn func()
+
+	// decoders decode the values of each column for Next. They are resolved from schema on the first call.
+	decoders []decoder
+	// names are the column names for NextBatch
+	names []string
 }
 
+// fetchResult is a result of fetchfn
+type fetchResult struct {
+	resp *cli_service.TFetchResultsResp
+	err  error
+}
+
+// errResultSetClosed is returned by Next after Close stopped the prefetching
+var errResultSetClosed = errors.New("result set is closed")
+
 // Next ...
 func (rs *ResultSet) Next(dest []driver.Value) error {
+	if err := rs.fill(); err != nil {
+		return err
+	}
+	if rs.idx >= rs.length {
+		return io.EOF
+	}
+
+	if rs.decoders == nil {
+		rs.decoders = make([]decoder, len(rs.schema.Columns))
+		for i, cd := range rs.schema.Columns {
+			rs.decoders[i] = decoderFor(cd)
+		}
+	}
+	for i := range dest {
+		val, err := rs.decoders[i](rs.result.Columns[i], rs.idx)
+		if err != nil {
+			return err
+		}
+		dest[i] = val
+	}
+	rs.idx++
+	return nil
+}
+
+// fill fetches batches until one has rows or there are no more rows
+func (rs *ResultSet) fill() error {
 	for rs.idx >= rs.length && rs.more {
 		// We don't sleep intentionally between loops following the example from impala-shell
 		// https://github.com/apache/impala/blob/1f35747/shell/impala_client.py#L958
-		resp, err := rs.fetchfn()
+		resp, err := rs.nextBatch()
 		if err != nil {
 			return err
 		}
@@ -37,20 +91,75 @@ func (rs *ResultSet) Next(dest []driver.Value) error {
 		// FETCH_ROWS_TIMEOUT_MS was reached. We keep calling fetchfn in that case
 		// until query completes, fails, times out (QUERY_TIMEOUT_MS), or context is cancelled.
 	}
+	return nil
+}
 
-	if rs.idx >= rs.length {
-		return io.EOF
+// nextBatch fetches the next batch of rows or takes it from the prefetched ones
+func (rs *ResultSet) nextBatch() (*cli_service.TFetchResultsResp, error) {
+	if rs.prefetch <= 0 {
+		return rs.fetchfn()
+	}
+	if rs.batches == nil {
+		// the goroutine holds a batch while it waits to send it, so it is one batch ahead of the channel
+		rs.batches = make(chan fetchResult, rs.prefetch-1)
+		rs.stop = make(chan struct{})
+		go rs.prefetchBatches(rs.batches, rs.stop)
+	}
+	for {
+		select {
+		case <-rs.polls:
+			rs.progressfn()
+		case b, ok := <-rs.batches:
+			if !ok {
+				if err := rs.ctx.Err(); err != nil {
+					return nil, err
+				}
+				return nil, errResultSetClosed
+			}
+			return b.resp, b.err
+		}
 	}
+}
 
-	for i := range dest {
-		val, err := value(rs.result.Columns[i], rs.schema.Columns[i], rs.idx)
-		if err != nil {
-			return err
+// prefetchBatches sends the batches of the result set to batches until the last one, an error, ctx is done
+// or stop is closed. It closes batches when it ends.
+func (rs *ResultSet) prefetchBatches(batches chan<- fetchResult, stop <-chan struct{}) {
+	defer close(batches)
+	for {
+		// stop before fetching another batch, even if the last one was sent while stopping
+		select {
+		case <-stop:
+			return
+		case <-rs.ctx.Done():
+			return
+		default:
+		}
+		resp, err := rs.fetchfn()
+		select {
+		case batches <- fetchResult{resp: resp, err: err}:
+		case <-stop:
+			return
+		case <-rs.ctx.Done():
+			return
+		}
+		if err != nil || !resp.GetHasMoreRows() {
+			return
 		}
-		dest[i] = val
 	}
-	rs.idx++
-	return nil
+}
+
+// Close stops prefetching and waits until the fetch in progress, if any, ends,
+// so that the operation can be closed safely. Calling Close more than once does nothing.
+func (rs *ResultSet) Close() {
+	if rs.stop == nil {
+		return
+	}
+	close(rs.stop)
+	for range rs.batches {
+		// drain until the goroutine ends
+	}
+	rs.stop = nil
+	rs.more = false
 }
 
 // isSet checks if the i-th member of the provided bitmap is set. Each byte contains 8 bit flags.
@@ -58,59 +167,69 @@ func isSet(bitmap []byte, i int) bool {
 	return bitmap[i/8]&(1<<(uint(i)%8)) != 0
 }
 
-func value(col *cli_service.TColumn, cd *ColDesc, i int) (any, error) {
+// decoder returns the value at index i of a column
+type decoder func(col *cli_service.TColumn, i int) (driver.Value, error)
+
+// decoderFor returns the decoder of the column described by cd. Resolving the decoder once per column
+// avoids matching the type name for each value.
+func decoderFor(cd *ColDesc) decoder {
 	switch cd.DatabaseTypeName {
 	case "STRING", "CHAR", "VARCHAR":
-		if isSet(col.StringVal.Nulls, i) {
-			return nil, nil
-		}
-		return col.StringVal.Values[i], nil
+		return vectorDecoder(stringValues)
 	case "TINYINT":
-		if isSet(col.ByteVal.Nulls, i) {
-			return nil, nil
-		}
-		return col.ByteVal.Values[i], nil
+		return vectorDecoder(func(col *cli_service.TColumn) ([]int8, []byte) {
+			return col.ByteVal.Values, col.ByteVal.Nulls
+		})
 	case "SMALLINT":
-		if isSet(col.I16Val.Nulls, i) {
-			return nil, nil
-		}
-		return col.I16Val.Values[i], nil
+		return vectorDecoder(func(col *cli_service.TColumn) ([]int16, []byte) {
+			return col.I16Val.Values, col.I16Val.Nulls
+		})
 	case "INT":
-		if isSet(col.I32Val.Nulls, i) {
-			return nil, nil
-		}
-		return col.I32Val.Values[i], nil
+		return vectorDecoder(func(col *cli_service.TColumn) ([]int32, []byte) {
+			return col.I32Val.Values, col.I32Val.Nulls
+		})
 	case "BIGINT":
-		if isSet(col.I64Val.Nulls, i) {
-			return nil, nil
-		}
-		return col.I64Val.Values[i], nil
+		return vectorDecoder(func(col *cli_service.TColumn) ([]int64, []byte) {
+			return col.I64Val.Values, col.I64Val.Nulls
+		})
 	case "BOOLEAN":
-		if isSet(col.BoolVal.Nulls, i) {
-			return nil, nil
-		}
-		return col.BoolVal.Values[i], nil
+		return vectorDecoder(func(col *cli_service.TColumn) ([]bool, []byte) {
+			return col.BoolVal.Values, col.BoolVal.Nulls
+		})
 	case "FLOAT", "DOUBLE":
 		// we could return float values as float32(col.DoubleVal.Values[i])
 		// but it is not worth the complexity
-		if isSet(col.DoubleVal.Nulls, i) {
-			return nil, nil
-		}
-		return col.DoubleVal.Values[i], nil
+		return vectorDecoder(func(col *cli_service.TColumn) ([]float64, []byte) {
+			return col.DoubleVal.Values, col.DoubleVal.Nulls
+		})
 	case "TIMESTAMP", "DATETIME":
-		if isSet(col.StringVal.Nulls, i) {
-			return nil, nil
-		}
-		t, err := time.Parse(TimestampFormat, col.StringVal.Values[i])
-		if err != nil {
-			return nil, err
+		return func(col *cli_service.TColumn, i int) (driver.Value, error) {
+			if isSet(col.StringVal.Nulls, i) {
+				return nil, nil
+			}
+			t, err := time.Parse(TimestampFormat, col.StringVal.Values[i])
+			if err != nil {
+				return nil, err
+			}
+			return t, nil
 		}
-		return t, nil
 	default:
-		if isSet(col.StringVal.Nulls, i) {
+		return vectorDecoder(stringValues)
+	}
+}
+
+func stringValues(col *cli_service.TColumn) ([]string, []byte) {
+	return col.StringVal.Values, col.StringVal.Nulls
+}
+
+// vectorDecoder returns a decoder of the values that vector returns for a column
+func vectorDecoder[T any](vector func(col *cli_service.TColumn) ([]T, []byte)) decoder {
+	return func(col *cli_service.TColumn, i int) (driver.Value, error) {
+		values, nulls := vector(col)
+		if isSet(nulls, i) {
 			return nil, nil
 		}
-		return col.StringVal.Values[i], nil
+		return values[i], nil
 	}
 }
 
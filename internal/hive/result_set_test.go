@@ -1,7 +1,9 @@
 package hive
 
 import (
+	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"io"
 	"testing"
@@ -46,6 +48,49 @@ func TestResultSet(t *testing.T) {
 		err = rs.Next(data)
 		require.Equal(t, io.EOF, err)
 	})
+
+	t.Run("prefetch", func(t *testing.T) {
+		intColumn := func(v int32) []*cli_service.TColumn {
+			return []*cli_service.TColumn{{I32Val: &cli_service.TI32Column{Nulls: []byte{0}, Values: []int32{v}}}}
+		}
+		r := &results{data: []any{intColumn(1), intColumn(2), errors.New("fetch failed"), intColumn(3)}}
+		rs := ResultSet{
+			fetchfn:  r.fetch,
+			more:     true,
+			schema:   &TableSchema{Columns: []*ColDesc{{DatabaseTypeName: "INT"}}},
+			prefetch: 2,
+			ctx:      context.Background(),
+		}
+		data := make([]driver.Value, 1)
+		require.NoError(t, rs.Next(data))
+		require.EqualValues(t, 1, data[0])
+		require.NoError(t, rs.Next(data))
+		require.EqualValues(t, 2, data[0])
+		require.EqualError(t, rs.Next(data), "fetch failed")
+		rs.Close()
+		// the goroutine stopped at the error
+		require.Equal(t, 3, r.idx)
+		rs.Close()
+	})
+
+	t.Run("close before the end", func(t *testing.T) {
+		r := &results{data: make([]any, 10)}
+		for i := range r.data {
+			r.data[i] = []*cli_service.TColumn{{I32Val: &cli_service.TI32Column{Nulls: []byte{0}, Values: []int32{int32(i)}}}}
+		}
+		rs := ResultSet{
+			fetchfn:  r.fetch,
+			more:     true,
+			schema:   &TableSchema{Columns: []*ColDesc{{DatabaseTypeName: "INT"}}},
+			prefetch: 3,
+			ctx:      context.Background(),
+		}
+		data := make([]driver.Value, 1)
+		require.NoError(t, rs.Next(data))
+		rs.Close()
+		require.LessOrEqual(t, r.idx, 4)
+		require.Equal(t, io.EOF, rs.Next(data))
+	})
 }
 
 type results struct {
@@ -2,6 +2,10 @@ package hive
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/sclgo/impala-go/internal/generated/cli_service"
 )
@@ -10,6 +14,40 @@ import (
 type Session struct {
 	hive *Client
 	h    *cli_service.TSessionHandle
+	user string
+	// serverConfig is the configuration that the server returned when the session was opened, or nil if unknown.
+	// Impala returns all query options that it knows.
+	serverConfig map[string]string
+}
+
+// Handle returns the session handle, which identifies the session on the server
+func (s *Session) Handle() *cli_service.TSessionHandle {
+	return s.h
+}
+
+// AttachOperation returns an operation in the session with the given handle, possibly started by another client
+func (s *Session) AttachOperation(h *cli_service.TOperationHandle) *Operation {
+	return &Operation{h: h, hive: s.hive, session: s.h}
+}
+
+// AttachQuery returns an operation in the session for the query with the given Impala query ID.
+// The query may run in another session. Only the methods that Impala supports for such queries,
+// such as RuntimeProfile, can be used with the returned operation.
+func (s *Session) AttachQuery(queryID string) (*Operation, error) {
+	guid, err := parseQueryID(queryID)
+	if err != nil {
+		return nil, err
+	}
+	h := &cli_service.TOperationHandle{
+		OperationId:   &cli_service.THandleIdentifier{GUID: guid, Secret: s.h.GetSessionId().GetSecret()},
+		OperationType: cli_service.TOperationType_EXECUTE_STATEMENT,
+	}
+	return s.AttachOperation(h), nil
+}
+
+// ImpersonatedUser returns the user that the session runs queries as, or empty string without impersonation
+func (s *Session) ImpersonatedUser() string {
+	return s.user
 }
 
 // Ping checks the connection
@@ -32,23 +70,69 @@ func (s *Session) Ping(ctx context.Context) error {
 }
 
 // ExecuteStatement returns hive operation
-func (s *Session) ExecuteStatement(ctx context.Context, stmt string) (*Operation, error) {
+// The query options, if any, apply only to the statement. They are sent as the confOverlay of the request.
+func (s *Session) ExecuteStatement(ctx context.Context, stmt string, options map[string]string) (*Operation, error) {
+	if err := s.checkQueryOptions(options); err != nil {
+		return nil, err
+	}
 	req := cli_service.TExecuteStatementReq{
 		SessionHandle: s.h,
 		Statement:     stmt,
+		ConfOverlay:   options,
 	}
 	resp, err := s.hive.client.ExecuteStatement(ctx, &req)
 
 	if err != nil {
 		return nil, err
 	}
-	if err = s.checkStatus(resp); err != nil {
+	if err = checkStatus(resp); err != nil {
 		return nil, err
 	}
-	s.hive.log.Printf("execute operation: %s; stmt: %s; status code: %s", guid(resp.OperationHandle.OperationId.GUID), stmt, resp.GetStatus().GetStatusCode())
+	s.hive.log.Printf("execute operation: %s; stmt: %s; options: %v; status code: %s", guid(resp.OperationHandle.OperationId.GUID), stmt, options, resp.GetStatus().GetStatusCode())
 	s.hive.log.Printf("operation. has resultset: %v", resp.OperationHandle.GetHasResultSet())
 	s.hive.log.Printf("operation. modified row count: %f", resp.OperationHandle.GetModifiedRowCount())
-	return &Operation{h: resp.OperationHandle, hive: s.hive}, nil
+	op := &Operation{h: resp.OperationHandle, hive: s.hive, session: s.h}
+	op.keepInfoMessages(resp.GetStatus())
+	return op, nil
+}
+
+// exec executes a statement that returns no rows and waits for it to finish
+func (s *Session) exec(ctx context.Context, stmt string) error {
+	op, err := s.ExecuteStatement(ctx, stmt, nil)
+	if err != nil {
+		return err
+	}
+	err = op.WaitToFinish(ctx)
+	_ = withFallbackCtx(ctx, func(ctx context.Context) error {
+		_, closeErr := op.Close(ctx)
+		err = errors.Join(err, closeErr)
+		return closeErr
+	})
+	return err
+}
+
+// checkQueryOptions verifies that the server knows all query options, if the server returned its options
+// when the session was opened. Unknown options are an error only with StrictSessionConfig, like in OpenSession.
+func (s *Session) checkQueryOptions(options map[string]string) error {
+	if len(options) == 0 || s.serverConfig == nil {
+		return nil
+	}
+	var unknown []string
+	for key := range options {
+		if _, ok := lookupOption(s.serverConfig, key); !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	slices.Sort(unknown)
+	err := fmt.Errorf("%w: %s", ErrUnknownQueryOption, strings.Join(unknown, ", "))
+	if s.hive.opts.StrictSessionConfig {
+		return err
+	}
+	s.hive.log.Println(err)
+	return nil
 }
 
 func (s *Session) checkStatus(resp rpcResponse) error {
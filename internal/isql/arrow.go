@@ -0,0 +1,148 @@
+package isql
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sclgo/impala-go/internal/arrow"
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/hive"
+)
+
+// WriteArrow writes the remaining rows as an Arrow IPC stream to w, with a record batch for each fetched batch
+func (r *Rows) WriteArrow(w io.Writer) error {
+	fields := make([]arrow.Field, len(r.schema.Columns))
+	for i, cd := range r.schema.Columns {
+		fields[i] = arrow.Field{Name: cd.Name, Type: arrowType(cd), Nullable: !cd.NotNull}
+	}
+	aw, err := arrow.NewWriter(w, fields)
+	if err != nil {
+		return err
+	}
+	for {
+		batch, err := r.NextBatch()
+		if err == io.EOF {
+			return aw.Close()
+		}
+		if err != nil {
+			return err
+		}
+		arrays := make([]arrow.Array, len(fields))
+		for i, cd := range r.schema.Columns {
+			if arrays[i], err = arrowArray(cd, batch.Columns[i], batch.Len); err != nil {
+				return fmt.Errorf("column %s: %w", cd.Name, err)
+			}
+		}
+		if err = aw.Write(batch.Len, arrays); err != nil {
+			return err
+		}
+	}
+}
+
+// arrowType returns the Arrow type of the column. Types without an Arrow equivalent, e.g. complex types, are Utf8,
+// because Impala returns them as text.
+func arrowType(cd *hive.ColDesc) arrow.Type {
+	switch cd.TypeID {
+	case cli_service.TTypeId_NULL_TYPE:
+		return arrow.Null
+	case cli_service.TTypeId_BOOLEAN_TYPE:
+		return arrow.Bool
+	case cli_service.TTypeId_TINYINT_TYPE:
+		return arrow.Int(8, true)
+	case cli_service.TTypeId_SMALLINT_TYPE:
+		return arrow.Int(16, true)
+	case cli_service.TTypeId_INT_TYPE:
+		return arrow.Int(32, true)
+	case cli_service.TTypeId_BIGINT_TYPE:
+		return arrow.Int(64, true)
+	case cli_service.TTypeId_FLOAT_TYPE:
+		return arrow.Float32
+	case cli_service.TTypeId_DOUBLE_TYPE:
+		return arrow.Float64
+	case cli_service.TTypeId_DECIMAL_TYPE:
+		if cd.HasPrecisionScale {
+			return arrow.Decimal128(int(cd.Precision), int(cd.Scale))
+		}
+	case cli_service.TTypeId_TIMESTAMP_TYPE:
+		return arrow.TimestampNano
+	case cli_service.TTypeId_DATE_TYPE:
+		return arrow.Date32
+	case cli_service.TTypeId_BINARY_TYPE:
+		return arrow.Binary
+	}
+	return arrow.Utf8
+}
+
+// arrowArray returns the n values of a column vector of hive.Batch as an Arrow array of the type of the column
+func arrowArray(cd *hive.ColDesc, vector any, n int) (arrow.Array, error) {
+	if cd.TypeID == cli_service.TTypeId_NULL_TYPE {
+		return arrow.NullArray(n), nil
+	}
+	switch v := vector.(type) {
+	case hive.Vector[bool]:
+		return arrow.BoolArray(v.Values, v.Nulls), nil
+	case hive.Vector[int8]:
+		return arrow.PrimitiveArray(v.Values, v.Nulls), nil
+	case hive.Vector[int16]:
+		return arrow.PrimitiveArray(v.Values, v.Nulls), nil
+	case hive.Vector[int32]:
+		return arrow.PrimitiveArray(v.Values, v.Nulls), nil
+	case hive.Vector[int64]:
+		return arrow.PrimitiveArray(v.Values, v.Nulls), nil
+	case hive.Vector[float64]:
+		if cd.TypeID == cli_service.TTypeId_FLOAT_TYPE {
+			values := make([]float32, len(v.Values))
+			for i, f := range v.Values {
+				values[i] = float32(f)
+			}
+			return arrow.PrimitiveArray(values, v.Nulls), nil
+		}
+		return arrow.PrimitiveArray(v.Values, v.Nulls), nil
+	case hive.Vector[[]byte]:
+		return arrow.VarBinaryArray(v.Values, v.Nulls)
+	case hive.Vector[string]:
+		return textArray(cd, v)
+	}
+	return arrow.Array{}, fmt.Errorf("unexpected vector %T", vector)
+}
+
+// textArray returns the values of a column that Impala sends as text, parsed according to the type of the column
+func textArray(cd *hive.ColDesc, v hive.Vector[string]) (arrow.Array, error) {
+	switch cd.TypeID {
+	case cli_service.TTypeId_TIMESTAMP_TYPE:
+		values := make([]int64, v.Len())
+		for i, s := range v.Values {
+			if v.IsNull(i) {
+				continue
+			}
+			t, err := time.Parse(hive.TimestampFormat, s)
+			if err != nil {
+				return arrow.Array{}, err
+			}
+			values[i] = t.UnixNano()
+			if !time.Unix(0, values[i]).Equal(t) {
+				return arrow.Array{}, fmt.Errorf("timestamp %s is out of the range of nanosecond timestamps", s)
+			}
+		}
+		return arrow.PrimitiveArray(values, v.Nulls), nil
+	case cli_service.TTypeId_DATE_TYPE:
+		values := make([]int32, v.Len())
+		for i, s := range v.Values {
+			if v.IsNull(i) {
+				continue
+			}
+			t, err := time.Parse(time.DateOnly, s)
+			if err != nil {
+				return arrow.Array{}, err
+			}
+			values[i] = int32(t.Unix() / (24 * 60 * 60))
+		}
+		return arrow.PrimitiveArray(values, v.Nulls), nil
+	case cli_service.TTypeId_DECIMAL_TYPE:
+		if cd.HasPrecisionScale {
+			return arrow.Decimal128Array(v.Values, v.Nulls, int(cd.Scale))
+		}
+	}
+	return arrow.VarBinaryArray(v.Values, v.Nulls)
+}
@@ -0,0 +1,48 @@
+package isql
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/hive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrowType(t *testing.T) {
+	decimal := &hive.ColDesc{TypeID: cli_service.TTypeId_DECIMAL_TYPE, Precision: 10, Scale: 2, HasPrecisionScale: true}
+	require.Equal(t, "decimal128(10, 2)", arrowType(decimal).String())
+	decimal.HasPrecisionScale = false
+	require.Equal(t, "utf8", arrowType(decimal).String())
+	require.Equal(t, "int16", arrowType(&hive.ColDesc{TypeID: cli_service.TTypeId_SMALLINT_TYPE}).String())
+	require.Equal(t, "timestamp[ns]", arrowType(&hive.ColDesc{TypeID: cli_service.TTypeId_TIMESTAMP_TYPE}).String())
+	require.Equal(t, "utf8", arrowType(&hive.ColDesc{TypeID: cli_service.TTypeId_ARRAY_TYPE}).String())
+}
+
+func TestTextArray(t *testing.T) {
+	timestamps := hive.Vector[string]{
+		Values: []string{"1970-01-01 00:00:01.5", "", "1969-12-31 23:59:59"},
+		Nulls:  []byte{0b010},
+	}
+	a, err := textArray(&hive.ColDesc{TypeID: cli_service.TTypeId_TIMESTAMP_TYPE}, timestamps)
+	require.NoError(t, err)
+	require.Equal(t, 1, a.NullCount)
+	require.Equal(t, int64s(1_500_000_000, 0, -1_000_000_000), a.Buffers[1])
+
+	dates := hive.Vector[string]{Values: []string{"1970-01-02", "1969-12-31"}}
+	a, err = textArray(&hive.ColDesc{TypeID: cli_service.TTypeId_DATE_TYPE}, dates)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}, a.Buffers[1])
+
+	_, err = textArray(&hive.ColDesc{TypeID: cli_service.TTypeId_TIMESTAMP_TYPE},
+		hive.Vector[string]{Values: []string{"9999-12-31 00:00:00"}})
+	require.ErrorContains(t, err, "out of the range")
+}
+
+func int64s(values ...int64) []byte {
+	var data []byte
+	for _, v := range values {
+		data = binary.LittleEndian.AppendUint64(data, uint64(v))
+	}
+	return data
+}
@@ -0,0 +1,244 @@
+package isql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sclgo/impala-go/internal/generated/cli_service"
+	"github.com/sclgo/impala-go/internal/hive"
+)
+
+// AsyncHandle identifies an operation started by Submit, together with its dedicated session
+type AsyncHandle struct {
+	Session   *cli_service.TSessionHandle
+	Operation *cli_service.TOperationHandle
+}
+
+// Submit starts stmt in a new dedicated session and returns without waiting for it to finish.
+// Unlike the session of the connection, the dedicated session is not closed when the connection is reset
+// or closed, so the operation can be accessed from other connections. AsyncClose closes both.
+func (c *Conn) Submit(ctx context.Context, stmt string) (AsyncHandle, error) {
+	if !c.isTransportOpen() {
+		return AsyncHandle{}, fmt.Errorf("%w: underlying connection is not open", driver.ErrBadConn)
+	}
+	session, err := c.openSession(ctx, c.impersonateUser(ctx))
+	if err != nil {
+		return AsyncHandle{}, err
+	}
+	op, err := session.ExecuteStatement(ctx, stmt, queryOptions(ctx))
+	if err != nil {
+		_ = session.Close(ctx)
+		return AsyncHandle{}, mapErr(err)
+	}
+	c.notifyQueryID(ctx, op)
+	return AsyncHandle{Session: session.Handle(), Operation: op.Handle()}, nil
+}
+
+// AsyncResult is the result of ExecContext in async exec mode. The statement may still be running.
+// Implements [driver.Result].
+type AsyncResult struct {
+	// Handle identifies the statement and its dedicated session. See Submit.
+	Handle AsyncHandle
+}
+
+// LastInsertId is not supported. Implements [driver.Result].
+func (r *AsyncResult) LastInsertId() (int64, error) {
+	return driver.RowsAffected(0).LastInsertId()
+}
+
+// RowsAffected returns an error because the statement may not be finished. Implements [driver.Result].
+func (r *AsyncResult) RowsAffected() (int64, error) {
+	return 0, errors.New("impala: rows affected are not known until the async statement finishes")
+}
+
+type asyncExecKey struct{}
+
+// WithAsyncExec returns a context that makes ExecContext return as soon as the server accepts the statement,
+// overriding Options.AsyncExec
+func WithAsyncExec(ctx context.Context, async bool) context.Context {
+	return context.WithValue(ctx, asyncExecKey{}, async)
+}
+
+// asyncExec tells if ExecContext must run statements with ctx in async exec mode
+func (c *Conn) asyncExec(ctx context.Context) bool {
+	if async, ok := ctx.Value(asyncExecKey{}).(bool); ok {
+		return async
+	}
+	return c.opts.AsyncExec
+}
+
+// AsyncHandleFunc receives the handle of each statement that ExecContext starts in async exec mode
+type AsyncHandleFunc func(ctx context.Context, h AsyncHandle)
+
+type asyncHandleFuncKey struct{}
+
+// WithAsyncHandleFunc returns a context that makes ExecContext pass the handles of statements
+// started in async exec mode to fn
+func WithAsyncHandleFunc(ctx context.Context, fn AsyncHandleFunc) context.Context {
+	return context.WithValue(ctx, asyncHandleFuncKey{}, fn)
+}
+
+// execAsync starts stmt like Submit and tracks it until it is closed with AsyncClose or the connection closes
+func (c *Conn) execAsync(ctx context.Context, stmt string) (driver.Result, error) {
+	h, err := c.Submit(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	c.pending.add(c, h)
+	if fn, ok := ctx.Value(asyncHandleFuncKey{}).(AsyncHandleFunc); ok && fn != nil {
+		fn(ctx, h)
+	}
+	return &AsyncResult{Handle: h}, nil
+}
+
+// PendingStatements tracks the statements started in async exec mode that are not closed yet.
+// When connections share it, a statement that AsyncClose closed on any of them is not closed again
+// when the connection that started it closes. It is safe for concurrent use. The zero value is ready to use.
+type PendingStatements struct {
+	mu sync.Mutex
+	// owners are the connections that started the statements, by operation GUID
+	owners  map[string]*Conn
+	handles map[string]AsyncHandle
+}
+
+func (p *PendingStatements) add(c *Conn, h AsyncHandle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.owners == nil {
+		p.owners = make(map[string]*Conn)
+		p.handles = make(map[string]AsyncHandle)
+	}
+	key := string(h.Operation.GetOperationId().GetGUID())
+	p.owners[key] = c
+	p.handles[key] = h
+}
+
+func (p *PendingStatements) remove(h AsyncHandle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := string(h.Operation.GetOperationId().GetGUID())
+	delete(p.owners, key)
+	delete(p.handles, key)
+}
+
+// take removes the statements that c started and returns them
+func (p *PendingStatements) take(c *Conn) []AsyncHandle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var handles []AsyncHandle
+	for key, owner := range p.owners {
+		if owner == c {
+			handles = append(handles, p.handles[key])
+			delete(p.owners, key)
+			delete(p.handles, key)
+		}
+	}
+	return handles
+}
+
+// closePending waits for the statements started in async exec mode to finish, up to Options.AsyncExecCloseTimeout,
+// cancels the ones that are still running and closes them. Failures are logged because the connection is closing.
+// Without a timeout, the statements are left running and the server closes their sessions once
+// its disconnected session timeout elapses.
+func (c *Conn) closePending() {
+	handles := c.pending.take(c)
+	if len(handles) == 0 {
+		return
+	}
+	if c.opts.AsyncExecCloseTimeout <= 0 {
+		c.log.Printf("leaving %d async statements to the server", len(handles))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.AsyncExecCloseTimeout)
+	defer cancel()
+	for _, h := range handles {
+		err := c.AsyncWait(ctx, h)
+		if ctx.Err() != nil {
+			c.log.Printf("cancelling async statement that didn't finish before closing the connection: %v", err)
+			err = c.AsyncCancel(context.Background(), h)
+		}
+		if err != nil {
+			c.log.Printf("async statement failed: %v", err)
+		}
+		if _, err = c.AsyncClose(context.Background(), h); err != nil {
+			c.log.Printf("failed to close async statement: %v", err)
+		}
+	}
+}
+
+// AsyncStatus returns the state of the operation and the error message if the operation failed
+func (c *Conn) AsyncStatus(ctx context.Context, h AsyncHandle) (cli_service.TOperationState, string, error) {
+	op, err := c.attach(h)
+	if err != nil {
+		return 0, "", err
+	}
+	state, errMsg, err := op.Status(ctx)
+	return state, errMsg, mapErr(withQueryID(err, op))
+}
+
+// AsyncWait waits for the operation to finish. It returns an error if the operation fails or is cancelled.
+func (c *Conn) AsyncWait(ctx context.Context, h AsyncHandle) error {
+	op, err := c.attach(h)
+	if err != nil {
+		return err
+	}
+	c.trackProgress(ctx, op)
+	return mapErr(withQueryID(op.WaitToFinish(ctx), op))
+}
+
+// AsyncCancel cancels the operation. It must still be closed with AsyncClose.
+func (c *Conn) AsyncCancel(ctx context.Context, h AsyncHandle) error {
+	op, err := c.attach(h)
+	if err != nil {
+		return err
+	}
+	return mapErr(withQueryID(op.Cancel(ctx), op))
+}
+
+// AsyncResults returns the result rows of the operation. Results can be fetched only once.
+// Closing the rows doesn't close the operation.
+func (c *Conn) AsyncResults(ctx context.Context, h AsyncHandle) (driver.Rows, error) {
+	op, err := c.attach(h)
+	if err != nil {
+		return nil, err
+	}
+	c.trackProgress(ctx, op)
+	schema, err := op.GetResultSetMetadata(ctx)
+	if err != nil {
+		return nil, mapErr(withQueryID(err, op))
+	}
+	rs, err := op.FetchResults(ctx, schema)
+	if err != nil {
+		return nil, mapErr(withQueryID(err, op))
+	}
+	return &Rows{
+		rs:      rs,
+		schema:  schema,
+		op:      op,
+		closefn: func() error { return nil },
+	}, nil
+}
+
+// AsyncClose closes the operation and its dedicated session. It returns the number of rows
+// affected by the operation, if any.
+func (c *Conn) AsyncClose(ctx context.Context, h AsyncHandle) (int64, error) {
+	op, err := c.attach(h)
+	if err != nil {
+		return 0, err
+	}
+	c.pending.remove(h)
+	// The session is closed even if closing the operation fails e.g. because it was already closed
+	rowsAffected, opErr := op.Close(ctx)
+	sessionErr := c.client.AttachSession(h.Session).Close(ctx)
+	return rowsAffected, mapErr(withQueryID(errors.Join(opErr, sessionErr), op))
+}
+
+func (c *Conn) attach(h AsyncHandle) (*hive.Operation, error) {
+	if !c.isTransportOpen() {
+		return nil, fmt.Errorf("%w: underlying connection is not open", driver.ErrBadConn)
+	}
+	return c.client.AttachSession(h.Session).AttachOperation(h.Operation), nil
+}
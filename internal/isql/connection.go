@@ -6,19 +6,156 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
 	"github.com/sclgo/impala-go/internal/hive"
 )
 
 var (
 	// ErrNotSupported means this operation is not supported by impala driver
 	ErrNotSupported = errors.New("impala: not supported")
+
+	// ErrOpenFailed means the driver failed to open a connection or a session.
+	ErrOpenFailed = errors.New("impala: failed to open connection")
 )
 
 type Options struct {
 	ReuseSession bool
+	// ImpersonateUser is the default user that sessions run queries as. See WithImpersonateUser.
+	ImpersonateUser string
+	// ProfileOnError attaches the runtime profile of failed queries to their errors. See ProfileError.
+	ProfileOnError bool
+	// FailOnRowErrors makes DML statements that report row errors fail with RowErrorsError
+	FailOnRowErrors bool
+	// ProgressFunc, if not nil, receives the progress of running queries. See WithProgressFunc.
+	ProgressFunc hive.ProgressFunc
+	// QueryLogFunc, if not nil, receives the log of each query before the query is closed. See WithQueryLogFunc.
+	QueryLogFunc QueryLogFunc
+	// MultiStatements makes connections split queries into statements at semicolons and run them one by one
+	MultiStatements bool
+	// AsyncExec makes ExecContext return as soon as the server accepts the statement. See WithAsyncExec.
+	AsyncExec bool
+	// AsyncExecCloseTimeout is how long closing a connection waits for the statements started in async exec mode
+	// before cancelling them. 0 means that the statements are left running. See closePending.
+	AsyncExecCloseTimeout time.Duration
+	// Pending, if not nil, tracks the statements started in async exec mode together with other connections
+	Pending *PendingStatements
+	// Retry, if not nil, makes connections run idempotent statements again after transient failures
+	Retry *RetryPolicy
+}
+
+type impersonateUserKey struct{}
+
+// WithImpersonateUser returns a context that makes connections run queries as the given user,
+// overriding Options.ImpersonateUser. An empty user disables impersonation.
+func WithImpersonateUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, impersonateUserKey{}, user)
+}
+
+// impersonateUser returns the user that a session opened with the given context must run queries as
+func (c *Conn) impersonateUser(ctx context.Context) string {
+	if user, ok := ctx.Value(impersonateUserKey{}).(string); ok {
+		return user
+	}
+	return c.opts.ImpersonateUser
+}
+
+type queryOptionsKey struct{}
+
+// WithQueryOptions returns a context that makes statements run with the given query options, replacing
+// the options of any outer WithQueryOptions. The options apply only to each statement, not to the session.
+func WithQueryOptions(ctx context.Context, options map[string]string) context.Context {
+	return context.WithValue(ctx, queryOptionsKey{}, maps.Clone(options))
+}
+
+// queryOptions returns the query options that statements run with ctx must use, or nil
+func queryOptions(ctx context.Context) map[string]string {
+	options, _ := ctx.Value(queryOptionsKey{}).(map[string]string)
+	return options
+}
+
+type progressFuncKey struct{}
+
+// WithProgressFunc returns a context that makes connections report the progress of queries to fn,
+// overriding Options.ProgressFunc. A nil fn disables progress reporting.
+func WithProgressFunc(ctx context.Context, fn hive.ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressFuncKey{}, fn)
+}
+
+// trackProgress sets the progress function that ctx requires, if any, on the operation
+func (c *Conn) trackProgress(ctx context.Context, operation *hive.Operation) {
+	fn, ok := ctx.Value(progressFuncKey{}).(hive.ProgressFunc)
+	if !ok {
+		fn = c.opts.ProgressFunc
+	}
+	operation.SetProgressFunc(fn)
+}
+
+// QueryIDFunc receives the Impala query ID of a statement as soon as the server accepts the statement
+type QueryIDFunc func(ctx context.Context, queryID string)
+
+type queryIDFuncKey struct{}
+
+// WithQueryIDFunc returns a context that makes connections pass the query ID of each statement to fn
+func WithQueryIDFunc(ctx context.Context, fn QueryIDFunc) context.Context {
+	return context.WithValue(ctx, queryIDFuncKey{}, fn)
+}
+
+// notifyQueryID records the query ID of the operation and passes it to the function that ctx requires, if any
+func (c *Conn) notifyQueryID(ctx context.Context, operation *hive.Operation) {
+	c.lastQueryID = operation.QueryID()
+	if fn, ok := ctx.Value(queryIDFuncKey{}).(QueryIDFunc); ok && fn != nil {
+		fn(ctx, operation.QueryID())
+	}
+}
+
+// DMLResultFunc receives the detailed result of a DML statement run by ExecContext
+type DMLResultFunc func(ctx context.Context, dml *impalaservice.TDmlResult_)
+
+type dmlResultFuncKey struct{}
+
+// WithDMLResultFunc returns a context that makes ExecContext pass the results of DML statements to fn
+func WithDMLResultFunc(ctx context.Context, fn DMLResultFunc) context.Context {
+	return context.WithValue(ctx, dmlResultFuncKey{}, fn)
+}
+
+// notifyDMLResult passes the DML result of res to the function that ctx requires, if any
+func notifyDMLResult(ctx context.Context, res *Result) {
+	if fn, ok := ctx.Value(dmlResultFuncKey{}).(DMLResultFunc); ok && fn != nil && res.DML != nil {
+		fn(ctx, res.DML)
+	}
+}
+
+// QueryLogFunc receives the operation log and the info messages of a query
+type QueryLogFunc func(ctx context.Context, queryID string, log string, infoMessages []string)
+
+type queryLogFuncKey struct{}
+
+// WithQueryLogFunc returns a context that makes connections report the log of queries to fn,
+// overriding Options.QueryLogFunc. A nil fn disables log reporting.
+func WithQueryLogFunc(ctx context.Context, fn QueryLogFunc) context.Context {
+	return context.WithValue(ctx, queryLogFuncKey{}, fn)
+}
+
+// reportQueryLog passes the log of the operation to the log function that ctx requires, if any.
+// Failing to get the log doesn't fail the query so the error is only logged and the info messages are still reported.
+func (c *Conn) reportQueryLog(ctx context.Context, operation *hive.Operation) {
+	fn, ok := ctx.Value(queryLogFuncKey{}).(QueryLogFunc)
+	if !ok {
+		fn = c.opts.QueryLogFunc
+	}
+	if fn == nil || ctx.Err() != nil {
+		return
+	}
+	log, err := operation.Log(ctx)
+	if err != nil {
+		c.log.Printf("failed to get the log of query %s: %v", operation.QueryID(), err)
+	}
+	fn(ctx, operation.QueryID(), log, operation.InfoMessages())
 }
 
 // Conn to impala. It should not be used concurrently by multiple goroutines.
@@ -28,6 +165,10 @@ type Conn struct {
 	client    *hive.Client
 	log       *log.Logger
 	opts      Options
+	// pending are the statements started in async exec mode that are not closed yet
+	pending *PendingStatements
+	// lastQueryID is the query ID of the last statement that the server accepted
+	lastQueryID string
 }
 
 // This declaration lists and verifies driver interfaces implemented by *Conn
@@ -64,10 +205,12 @@ func (c *Conn) isTransportOpen() bool {
 // validation and conversion as appropriate for the driver.
 // Implements driver.NamedValueChecker
 func (c *Conn) CheckNamedValue(val *driver.NamedValue) error {
-	t, ok := val.Value.(time.Time)
-	if ok {
-		val.Value = t.Format(hive.TimestampFormat)
+	switch v := val.Value.(type) {
+	case time.Time, Date:
+		// encoded by statement as typed literals
 		return nil
+	case driver.Valuer:
+		return checkValuer(val, v)
 	}
 	return driver.ErrSkip
 }
@@ -83,36 +226,113 @@ func (c *Conn) Prepare(query string) (driver.Stmt, error) {
 func (c *Conn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
 	return &Stmt{
 		conn: c,
-		stmt: query,
+		stmt: parseStatement(query),
 	}, nil
 }
 
 // QueryContext executes a query that may return rows
 // Implements driver.QueryerContext
 func (c *Conn) QueryContext(ctx context.Context, q string, args []driver.NamedValue) (driver.Rows, error) {
-	session, err := c.OpenSession(ctx) // also validates transport; err has driver.ErrBadConn in chain
+	return c.queryStatement(ctx, parseStatement(q), args)
+}
+
+func (c *Conn) queryStatement(ctx context.Context, parsed *statement, args []driver.NamedValue) (driver.Rows, error) {
+	stmt, err := parsed.bind(args)
 	if err != nil {
 		return nil, err
 	}
+	stmts := c.splitScript(stmt)
+	if len(stmts) > 1 {
+		session, err := c.OpenSession(ctx) // also validates transport; err has driver.ErrBadConn in chain
+		if err != nil {
+			return nil, err
+		}
+		return c.queryScript(ctx, session, stmts)
+	} else if len(stmts) == 1 {
+		stmt = stmts[0]
+	}
 
-	tmpl := template(q)
-	stmt := statement(tmpl, args)
-	rows, err := query(ctx, session, stmt)
-	return rows, mapErr(err)
+	var rows *Rows
+	err = c.retry(ctx, stmt, func() error {
+		session, err := c.OpenSession(ctx) // also validates transport; err has driver.ErrBadConn in chain
+		if err != nil {
+			return err
+		}
+		rows, err = c.query(ctx, session, stmt)
+		if err == nil {
+			err = c.waitForRows(ctx, stmt, rows)
+		}
+		return mapErr(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
 }
 
 // ExecContext executes a query that doesn't return rows
 // Implements driver.ExecerContext
 func (c *Conn) ExecContext(ctx context.Context, q string, args []driver.NamedValue) (driver.Result, error) {
-	session, err := c.OpenSession(ctx) // also validates transport; err has driver.ErrBadConn in chain
+	return c.execStatement(ctx, parseStatement(q), args)
+}
+
+func (c *Conn) execStatement(ctx context.Context, parsed *statement, args []driver.NamedValue) (driver.Result, error) {
+	stmt, err := parsed.bind(args)
 	if err != nil {
 		return nil, err
 	}
+	stmts := c.splitScript(stmt)
+	if len(stmts) == 1 {
+		stmt = stmts[0]
+	}
+	if c.asyncExec(ctx) {
+		if len(stmts) > 1 {
+			return nil, errors.New("impala: multi-statement queries can't run in async exec mode")
+		}
+		return c.execAsync(ctx, stmt)
+	}
+	if len(stmts) > 1 {
+		session, err := c.OpenSession(ctx) // also validates transport; err has driver.ErrBadConn in chain
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.execScript(ctx, session, stmts)
+		if err != nil {
+			return nil, err
+		}
+		notifyDMLResult(ctx, res)
+		return res, nil
+	}
 
-	tmpl := template(q)
-	stmt := statement(tmpl, args)
-	res, err := exec(ctx, session, stmt)
-	return res, mapErr(err)
+	var res *Result
+	err = c.retry(ctx, stmt, func() error {
+		session, err := c.OpenSession(ctx) // also validates transport; err has driver.ErrBadConn in chain
+		if err != nil {
+			return err
+		}
+		res, err = c.exec(ctx, session, stmt)
+		return mapErr(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	notifyDMLResult(ctx, res)
+	return res, nil
+}
+
+// RuntimeProfile returns the runtime profile of the query with the given Impala query ID.
+// The query may have been started by another connection.
+func (c *Conn) RuntimeProfile(ctx context.Context, queryID string, format runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error) {
+	session, err := c.OpenSession(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	op, err := session.AttachQuery(queryID)
+	if err != nil {
+		return "", nil, err
+	}
+	profile, tree, err := op.RuntimeProfile(ctx, format)
+	return profile, tree, mapErr(withQueryID(err, op))
 }
 
 // Begin is not supported
@@ -122,13 +342,22 @@ func (c *Conn) Begin() (driver.Tx, error) {
 }
 
 // OpenSession ensures opened session and live transport connection
-// Any returned errors have driver.ErrBadConn in the chain
+// Any returned errors have driver.ErrBadConn in the chain, except if the server opened the session
+// but didn't accept the session configuration. In that case, the error has ErrOpenFailed in the chain instead.
+// An existing session is reused only if it runs queries as the user that ctx requires - see WithImpersonateUser.
 func (c *Conn) OpenSession(ctx context.Context) (*hive.Session, error) {
+	user := c.impersonateUser(ctx)
+	if c.session != nil && c.session.ImpersonatedUser() != user {
+		c.log.Printf("closing session of user %q to open one for user %q", c.session.ImpersonatedUser(), user)
+		err := c.session.Close(ctx)
+		c.session = nil
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to close session of another user: %v", driver.ErrBadConn, err)
+		}
+	}
 	if c.session == nil {
-		session, err := c.client.OpenSession(ctx)
+		session, err := c.openSession(ctx, user)
 		if err != nil {
-			err = fmt.Errorf("%w: failed to open session: %v", driver.ErrBadConn, err)
-			c.log.Println(err)
 			return nil, err
 		}
 		c.session = session
@@ -143,6 +372,23 @@ func (c *Conn) OpenSession(ctx context.Context) (*hive.Session, error) {
 	return c.session, nil
 }
 
+// openSession opens a new session that is not tracked by c. See OpenSession for the returned errors.
+func (c *Conn) openSession(ctx context.Context, user string) (*hive.Session, error) {
+	session, err := c.client.OpenSession(ctx, user)
+	if errors.Is(err, hive.ErrSessionConfigDropped) {
+		// Retrying with another connection will not help
+		err = fmt.Errorf("%w: failed to open session: %w", ErrOpenFailed, err)
+		c.log.Println(err)
+		return nil, err
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: failed to open session: %v", driver.ErrBadConn, err)
+		c.log.Println(err)
+		return nil, err
+	}
+	return session, nil
+}
+
 // ResetSession closes hive session
 // Implements driver.SessionResetter
 func (c *Conn) ResetSession(ctx context.Context) (err error) {
@@ -172,6 +418,7 @@ func (c *Conn) ResetSession(ctx context.Context) (err error) {
 // Implements driver.Conn
 func (c *Conn) Close() error {
 	c.log.Printf("close connection")
+	c.closePending()
 	if c.session != nil {
 		err := c.session.Close(context.Background())
 		if err != nil {
@@ -196,10 +443,15 @@ func (c *Conn) IsValid() bool {
 }
 
 func NewConn(client *hive.Client, transport thrift.TTransport, logger *log.Logger, opts Options) *Conn {
+	pending := opts.Pending
+	if pending == nil {
+		pending = &PendingStatements{}
+	}
 	return &Conn{
 		transport: transport,
 		client:    client,
 		log:       logger,
 		opts:      opts,
+		pending:   pending,
 	}
 }
@@ -4,14 +4,72 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/apache/thrift/lib/go/thrift"
 	"github.com/murfffi/gorich/helperr"
 	"github.com/samber/lo"
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
 	"github.com/sclgo/impala-go/internal/hive"
 )
 
+// ErrRowErrors means that a DML statement completed but some row operations failed with non-fatal errors
+var ErrRowErrors = errors.New("impala: row operations failed")
+
+// RowErrorsError is returned with Options.FailOnRowErrors when a DML statement reports row errors
+type RowErrorsError struct {
+	// Result is the result of the statement
+	Result *impalaservice.TDmlResult_
+}
+
+func (e *RowErrorsError) Error() string {
+	return fmt.Sprintf("%v: %d row operations failed with non-fatal errors", ErrRowErrors, e.Result.GetNumRowErrors())
+}
+
+func (e *RowErrorsError) Unwrap() error {
+	return ErrRowErrors
+}
+
+// ProfileError is the error of a failed query with the runtime profile of the query attached
+type ProfileError struct {
+	err error
+	// Profile is the runtime profile in the string format
+	Profile string
+}
+
+func (e *ProfileError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ProfileError) Unwrap() error {
+	return e.err
+}
+
+// QueryIDError is the error of a query that the server accepted, with the Impala query ID attached
+type QueryIDError struct {
+	err error
+	// QueryID is the Impala query ID in the hi:lo format
+	QueryID string
+}
+
+func (e *QueryIDError) Error() string {
+	return fmt.Sprintf("%v (query id: %s)", e.err, e.QueryID)
+}
+
+func (e *QueryIDError) Unwrap() error {
+	return e.err
+}
+
+// withQueryID attaches the query ID of the operation to err, unless err is nil, io.EOF or already has a query ID
+func withQueryID(err error, operation *hive.Operation) error {
+	var idErr *QueryIDError
+	if err == nil || err == io.EOF || errors.As(err, &idErr) {
+		return err
+	}
+	return &QueryIDError{err: err, QueryID: operation.QueryID()}
+}
+
 func mapErr(err error) error {
 	if err == nil {
 		return nil
@@ -44,7 +102,16 @@ func mapErr(err error) error {
 }
 
 func wrapBadConn(err error) error {
-	// the input error is intentionally not wrapped to avoid exposing internals
+	// the input error is intentionally not wrapped to avoid exposing internals, except for the query ID
 	// guideline: https://go.dev/blog/go1.13-errors
+	var idErr *QueryIDError
+	if errors.As(err, &idErr) {
+		cause := err
+		if err == error(idErr) {
+			// the ID is added back below
+			cause = idErr.err
+		}
+		return &QueryIDError{err: fmt.Errorf("%w inferred from error: %v", driver.ErrBadConn, cause), QueryID: idErr.QueryID}
+	}
 	return fmt.Errorf("%w inferred from error: %v", driver.ErrBadConn, err)
 }
@@ -42,6 +42,18 @@ func TestMapErr(t *testing.T) {
 		require.ErrorContains(t, mappedErr, "inferred from error")
 	})
 
+	t.Run("keeps the query ID of bad connection errors", func(t *testing.T) {
+		err := &QueryIDError{err: thrift.NewTTransportException(thrift.END_OF_FILE, io.EOF.Error()), QueryID: "1:2"}
+
+		mappedErr := mapErr(err)
+
+		require.ErrorIs(t, mappedErr, driver.ErrBadConn)
+		var idErr *QueryIDError
+		require.ErrorAs(t, mappedErr, &idErr)
+		require.Equal(t, "1:2", idErr.QueryID)
+		require.Equal(t, "driver: bad connection inferred from error: EOF (query id: 1:2)", mappedErr.Error())
+	})
+
 	t.Run("does not map other thrift transport errors to bad connection", func(t *testing.T) {
 		err := thrift.NewTTransportException(thrift.TIMED_OUT, "transport timed out")
 
@@ -0,0 +1,156 @@
+package isql
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sclgo/impala-go/internal/hive"
+)
+
+// Date is a DATE value, without time of day and time zone
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// String returns the date in the yyyy-MM-dd format that Impala uses
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// decimal is the string value of a decimal number type. It is encoded as a number instead of a string literal.
+type decimal string
+
+var (
+	decimalPattern  = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	exponentPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?[eE][-+]?[0-9]+$`)
+)
+
+// decimalNumber is implemented by the decimal types of libraries like github.com/cockroachdb/apd,
+// github.com/shopspring/decimal and github.com/ericlagergren/decimal. String returns the number in
+// plain or exponent notation, e.g. 1.5 or 1.5E+3.
+type decimalNumber interface {
+	driver.Valuer
+	fmt.Stringer
+	Sign() int
+}
+
+// checkValuer resolves the driver.Valuer in val. Values of decimal number types are encoded as decimals,
+// or as DOUBLE values if they are in exponent notation. Other values are left for validation by
+// the default converter of database/sql.
+func checkValuer(val *driver.NamedValue, valuer driver.Valuer) error {
+	rv := reflect.ValueOf(valuer)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		// the default converter knows how to handle Value methods with value receivers on nil pointers
+		return driver.ErrSkip
+	}
+	if d, ok := valuer.(decimalNumber); ok {
+		return checkDecimal(val, d.String())
+	}
+	v, err := valuer.Value()
+	if err != nil {
+		return err
+	}
+	val.Value = v
+	return driver.ErrSkip
+}
+
+// checkDecimal sets val to the decimal s. Impala has no exponent notation for decimals, so such values
+// become float64. Values that are not finite numbers, like NaN, are rejected.
+func checkDecimal(val *driver.NamedValue, s string) error {
+	switch {
+	case decimalPattern.MatchString(s):
+		val.Value = decimal(s)
+	case exponentPattern.MatchString(s):
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("decimal argument %s is out of the DOUBLE range", s)
+		}
+		val.Value = f
+	default:
+		return fmt.Errorf("invalid decimal argument %q", s)
+	}
+	return nil
+}
+
+// literal encodes v as an Impala SQL expression that evaluates to v. The result is always
+// self-contained: it can't end a string literal, start a comment or join with the surrounding tokens.
+// Docs: https://impala.apache.org/docs/build/html/topics/impala_literals.html
+func literal(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return quote(v), nil
+	case []byte:
+		// Impala has no literal for arbitrary bytes. unhex returns a STRING with exactly these bytes.
+		return "unhex('" + hex.EncodeToString(v) + "')", nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case float64:
+		return floatLiteral(v, 64, "DOUBLE")
+	case float32:
+		return floatLiteral(float64(v), 32, "FLOAT")
+	case time.Time:
+		// TIMESTAMP has no time zone, and the driver reads TIMESTAMP values as UTC
+		return "CAST(" + quote(v.UTC().Format(hive.TimestampFormat)) + " AS TIMESTAMP)", nil
+	case Date:
+		t := time.Date(v.Year, v.Month, v.Day, 0, 0, 0, 0, time.UTC)
+		if t.Year() != v.Year || t.Month() != v.Month || t.Day() != v.Day {
+			return "", fmt.Errorf("invalid date %s", v)
+		}
+		return "CAST(" + quote(v.String()) + " AS DATE)", nil
+	case decimal:
+		return number(string(v)), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number(strconv.FormatInt(rv.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported argument type %T", v)
+}
+
+// floatLiteral casts f to the Impala type, because Impala reads numbers with a decimal point as DECIMAL
+func floatLiteral(f float64, bitSize int, typ string) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errors.New("NaN and infinite float arguments are not supported")
+	}
+	return "CAST(" + strconv.FormatFloat(f, 'g', -1, bitSize) + " AS " + typ + ")", nil
+}
+
+// number puts negative numbers in parentheses, so the minus sign can't form a -- comment with a preceding minus
+func number(s string) string {
+	if strings.HasPrefix(s, "-") {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+var quoteReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\x00", `\0`,
+)
+
+// quote returns s as an Impala string literal
+func quote(s string) string {
+	return "'" + quoteReplacer.Replace(s) + "'"
+}
@@ -0,0 +1,217 @@
+package isql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testDecimal mimics the decimal types of decimal libraries
+type testDecimal string
+
+func (d testDecimal) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+func (d testDecimal) String() string {
+	return string(d)
+}
+
+func (d testDecimal) Sign() int {
+	return 1
+}
+
+// signed has a Sign method but is not a decimal number
+type signed string
+
+func (s signed) Value() (driver.Value, error) {
+	return string(s), nil
+}
+
+func (s signed) Sign() int {
+	return 1
+}
+
+func TestLiteral(t *testing.T) {
+	ts := time.Date(2024, time.March, 1, 13, 14, 15, 123456000, time.UTC)
+	tests := []struct {
+		value  any
+		target string
+	}{
+		{value: nil, target: "NULL"},
+		{value: "plain", target: "'plain'"},
+		{value: `it's \ ok`, target: `'it\'s \\ ok'`},
+		{value: "a\nb\rc\x00d\"e", target: `'a\nb\rc\0d"e'`},
+		{value: []byte("a'\x00"), target: "unhex('612700')"},
+		{value: []byte{}, target: "unhex('')"},
+		{value: true, target: "TRUE"},
+		{value: false, target: "FALSE"},
+		{value: int64(42), target: "42"},
+		{value: int64(-42), target: "(-42)"},
+		{value: int8(-1), target: "(-1)"},
+		{value: uint64(math.MaxUint64), target: "18446744073709551615"},
+		{value: 0.1, target: "CAST(0.1 AS DOUBLE)"},
+		{value: -1e21, target: "CAST(-1e+21 AS DOUBLE)"},
+		{value: float32(0.5), target: "CAST(0.5 AS FLOAT)"},
+		{value: ts, target: "CAST('2024-03-01 13:14:15.123456' AS TIMESTAMP)"},
+		{value: ts.In(time.FixedZone("UTC+2", 2*60*60)), target: "CAST('2024-03-01 13:14:15.123456' AS TIMESTAMP)"},
+		{value: Date{Year: 2024, Month: time.February, Day: 29}, target: "CAST('2024-02-29' AS DATE)"},
+		{value: decimal("-12.50"), target: "(-12.50)"},
+	}
+	for _, tt := range tests {
+		result, err := literal(tt.value)
+		require.NoError(t, err, tt.value)
+		require.Equal(t, tt.target, result)
+	}
+
+	for _, value := range []any{math.NaN(), math.Inf(1), float32(math.Inf(-1)),
+		Date{Year: 2023, Month: time.February, Day: 29}, struct{}{}} {
+		_, err := literal(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestCheckNamedValue(t *testing.T) {
+	check := func(value any) (any, error) {
+		val := driver.NamedValue{Ordinal: 1, Value: value}
+		err := (&Conn{}).CheckNamedValue(&val)
+		return val.Value, err
+	}
+
+	ts := time.Now()
+	value, err := check(ts)
+	require.NoError(t, err)
+	require.Equal(t, ts, value)
+
+	value, err = check(testDecimal("-1.25"))
+	require.NoError(t, err)
+	require.Equal(t, decimal("-1.25"), value)
+
+	value, err = check(testDecimal("1E+3"))
+	require.NoError(t, err)
+	require.Equal(t, 1000.0, value)
+
+	value, err = check(testDecimal("-1.5e-3"))
+	require.NoError(t, err)
+	require.Equal(t, -0.0015, value)
+
+	for _, d := range []testDecimal{"NaN", "-Infinity", "1E+400", "1.", "1,5", ""} {
+		_, err = check(d)
+		require.Error(t, err, d)
+		require.NotErrorIs(t, err, driver.ErrSkip, d)
+	}
+
+	value, err = check(signed("12"))
+	require.ErrorIs(t, err, driver.ErrSkip)
+	require.Equal(t, "12", value)
+
+	value, err = check(sql.NullString{String: "123", Valid: true})
+	require.ErrorIs(t, err, driver.ErrSkip)
+	require.Equal(t, "123", value)
+
+	var nilDecimal *testDecimal
+	_, err = check(nilDecimal)
+	require.ErrorIs(t, err, driver.ErrSkip)
+
+	_, err = check("text")
+	require.ErrorIs(t, err, driver.ErrSkip)
+}
+
+// FuzzStatement checks that argument values can't change the token structure of a statement:
+// the tokens of the statement must be the tokens of the template with each placeholder replaced
+// by the tokens of its literal on its own.
+func FuzzStatement(f *testing.F) {
+	f.Add("it's", []byte("\x00"), int64(-5), 1.5, int64(0), true)
+	f.Add(`\'; DROP TABLE t; --`, []byte("'"), int64(0), -0.0, int64(1e18), false)
+	f.Add("@p2 */ /* -- \n'", []byte{}, int64(-9), math.NaN(), int64(-1), true)
+
+	const tmpl = "SELECT @p1, x-@p3, @p4/@p5 FROM t -- @p1 ?\n WHERE a = @p2 AND b IN (@p6, @p3) /* @p2 */ " +
+		"AND c > @p7 AND d = @p8 AND e = '@p1 ?'"
+	f.Fuzz(func(t *testing.T, s string, b []byte, i int64, fl float64, sec int64, bl bool) {
+		values := []any{s, b, i, fl, testDecimal(strings.TrimPrefix(s, "+")), time.Unix(sec, i).UTC(), bl, nil}
+		args := make([]driver.NamedValue, len(values))
+		expected := tokenize(t, tmpl)
+		for n, value := range values {
+			args[n] = driver.NamedValue{Ordinal: n + 1, Value: value}
+			if err := ignoreSkip((&Conn{}).CheckNamedValue(&args[n])); err != nil {
+				_, isDecimal := value.(testDecimal)
+				require.True(t, isDecimal, err)
+				return
+			}
+			lit, err := literal(args[n].Value)
+			if err != nil {
+				require.True(t, math.IsNaN(fl) || math.IsInf(fl, 0), err)
+				return
+			}
+			expected = replaceToken(expected, "@p"+string(rune('1'+n)), tokenize(t, lit))
+		}
+
+		stmt, err := parseStatement(tmpl).bind(args)
+		require.NoError(t, err)
+		require.Equal(t, expected, tokenize(t, stmt), stmt)
+	})
+}
+
+func ignoreSkip(err error) error {
+	if err == driver.ErrSkip {
+		return nil
+	}
+	return err
+}
+
+func replaceToken(tokens []string, token string, replacement []string) []string {
+	var result []string
+	for _, tok := range tokens {
+		if tok == token {
+			result = append(result, replacement...)
+		} else {
+			result = append(result, tok)
+		}
+	}
+	return result
+}
+
+// tokenize splits an Impala statement in tokens, approximating the Impala lexer
+func tokenize(t *testing.T, stmt string) []string {
+	var tokens []string
+	for i := 0; i < len(stmt); {
+		c := stmt[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case strings.HasPrefix(stmt[i:], "--"):
+			end := strings.IndexByte(stmt[i:], '\n')
+			if end < 0 {
+				end = len(stmt) - i
+			}
+			i += end
+		case strings.HasPrefix(stmt[i:], "/*"):
+			end := strings.Index(stmt[i+2:], "*/")
+			require.GreaterOrEqual(t, end, 0, "unterminated comment in %s", stmt)
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			for i++; i < len(stmt) && stmt[i] != c; i++ {
+				if stmt[i] == '\\' && c != '`' {
+					i++
+				}
+			}
+			require.Less(t, i, len(stmt), "unterminated quote in %s", stmt)
+			i++
+		case isWordByte(c) || c == '@':
+			for i++; i < len(stmt) && (isWordByte(stmt[i]) || stmt[i] == '.' ||
+				(stmt[i] == '+' || stmt[i] == '-') && (stmt[i-1] == 'e' || stmt[i-1] == 'E')); i++ {
+			}
+		default:
+			i++
+		}
+		tokens = append(tokens, stmt[start:i])
+	}
+	return tokens
+}
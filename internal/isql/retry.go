@@ -0,0 +1,201 @@
+package isql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/sclgo/impala-go/internal/hive"
+)
+
+// RetryPolicy makes connections run idempotent statements again after transport failures and retryable Impala errors
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of runs of a statement, including the first one
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with each retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Idempotent tells if a statement can run again safely. If nil, DefaultIdempotent is used.
+	Idempotent func(stmt string) bool
+	// Reconnect opens a new transport and client, replacing those of a connection after a transport failure
+	Reconnect func(ctx context.Context) (thrift.TTransport, *hive.Client, error)
+}
+
+// DefaultIdempotent returns true for read-only statements: SELECT, VALUES, WITH ... SELECT, SHOW, DESCRIBE and EXPLAIN
+func DefaultIdempotent(stmt string) bool {
+	stmt = strings.TrimLeft(stmt, " \t\r\n(")
+	for stmt != "" {
+		end := skipNonCode(stmt, 0)
+		if end == 0 || !isSpaceOrComment(stmt[:end]) {
+			break
+		}
+		stmt = strings.TrimLeft(stmt[end:], " \t\r\n(")
+	}
+	keyword := leadingWord(stmt)
+	if strings.EqualFold(keyword, "WITH") {
+		keyword = withQueryKeyword(stmt[len(keyword):])
+	}
+	switch strings.ToUpper(keyword) {
+	case "SELECT", "VALUES", "SHOW", "DESCRIBE", "EXPLAIN":
+		return true
+	}
+	return false
+}
+
+// leadingWord returns the word that s starts with
+func leadingWord(s string) string {
+	for i := 0; i < len(s); i++ {
+		if !isWordByte(s[i]) {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// withQueryKeyword returns the keyword of the statement that follows the common table expressions
+// of a WITH clause, or an empty string if it's not found. Table names can't be these keywords,
+// so the first one outside parentheses, string literals, quoted identifiers and comments is taken.
+func withQueryKeyword(s string) string {
+	depth := 0
+	for i := 0; i < len(s); {
+		if end := skipNonCode(s, i); end > i {
+			i = end
+			continue
+		}
+		switch c := s[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case isWordByte(c):
+			word := leadingWord(s[i:])
+			switch strings.ToUpper(word) {
+			case "SELECT", "VALUES", "INSERT", "UPSERT":
+				if depth == 0 {
+					return word
+				}
+			}
+			i += len(word)
+			continue
+		}
+		i++
+	}
+	return ""
+}
+
+// retryableMessages are parts of the messages of Impala errors that a retry may not get.
+// Impala reports them when executors fail or restart.
+var retryableMessages = []string{
+	"was retried",
+	"unreachable impalad",
+	"exec() rpc failed",
+	"rpc recv timed out",
+}
+
+// isRetryable tells if a statement that failed with err, mapped by mapErr, may succeed if it runs again
+func isRetryable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, retryable := range retryableMessages {
+		if strings.Contains(msg, retryable) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry calls run and calls it again while it fails with retryable errors, according to Options.Retry,
+// if stmt is idempotent. After transport failures, the connection reconnects before the next attempt.
+// Each failed attempt is logged with its query ID, and a successful retry with the query IDs of
+// the last failed and the new attempt.
+func (c *Conn) retry(ctx context.Context, stmt string, run func() error) error {
+	c.lastQueryID = ""
+	err := run()
+	if err == nil || !c.retries(stmt) {
+		return err
+	}
+
+	policy := c.opts.Retry
+	delay := policy.Backoff
+	for attempt := 1; attempt < policy.MaxAttempts && ctx.Err() == nil && isRetryable(err); attempt++ {
+		failedID := c.lastQueryID
+		c.log.Printf("retrying statement in %v after attempt %d failed (query id: %s): %v", delay, attempt, failedID, err)
+		if !sleep(ctx, delay) {
+			break
+		}
+		delay = min(2*delay, max(policy.MaxBackoff, policy.Backoff))
+
+		if errors.Is(err, driver.ErrBadConn) {
+			if reconnectErr := c.reconnect(ctx); reconnectErr != nil {
+				err = fmt.Errorf("%w; failed to reconnect for retry: %w", err, reconnectErr)
+				continue
+			}
+		}
+		c.lastQueryID = ""
+		if err = run(); err == nil {
+			c.log.Printf("query %s retried as query %s", failedID, c.lastQueryID)
+			return nil
+		}
+	}
+	return err
+}
+
+// retries tells if Options.Retry allows running stmt more than once
+func (c *Conn) retries(stmt string) bool {
+	policy := c.opts.Retry
+	if policy == nil || policy.MaxAttempts < 2 {
+		return false
+	}
+	idempotent := policy.Idempotent
+	if idempotent == nil {
+		idempotent = DefaultIdempotent
+	}
+	return idempotent(stmt)
+}
+
+// waitForRows waits until the rows of a query that may be retried are ready to fetch, so that the query fails
+// before it returns, when it can still be retried, instead of in the middle of reading the rows
+func (c *Conn) waitForRows(ctx context.Context, stmt string, rows *Rows) error {
+	if !c.retries(stmt) {
+		return nil
+	}
+	if err := rows.op.WaitToFinish(ctx); err != nil {
+		return c.closeAfterErr(ctx, rows.op, err)
+	}
+	return nil
+}
+
+// reconnect replaces the transport and client of the connection using Options.Retry.Reconnect.
+// The session of the connection is dropped without closing, because it was on the failed transport.
+func (c *Conn) reconnect(ctx context.Context) error {
+	if c.opts.Retry.Reconnect == nil {
+		return errors.New("impala: reconnecting is not supported")
+	}
+	transport, client, err := c.opts.Retry.Reconnect(ctx)
+	if err != nil {
+		return mapErr(err)
+	}
+	_ = c.transport.Close()
+	c.transport = transport
+	c.client = client
+	c.session = nil
+	return nil
+}
+
+// sleep waits for d or until ctx is done and returns false in the latter case
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
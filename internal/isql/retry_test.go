@@ -0,0 +1,66 @@
+package isql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/sclgo/impala-go/internal/hive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultIdempotent(t *testing.T) {
+	for _, stmt := range []string{
+		"SELECT 1",
+		"  select * from t",
+		"(SELECT 1) UNION (SELECT 2)",
+		"-- comment\n/* block */ SHOW TABLES",
+		"describe t",
+		"VALUES (1), (2)",
+		"explain SELECT 1",
+		"WITH x AS (SELECT 1) SELECT * FROM x",
+		"with x (a) as (select 1), `insert` as (values (2)) select * from x, `insert`",
+		"WITH x AS (SELECT 'INSERT') /* INSERT */ SELECT * FROM x",
+	} {
+		require.True(t, DefaultIdempotent(stmt), stmt)
+	}
+	for _, stmt := range []string{
+		"INSERT INTO t SELECT 1",
+		"WITH x AS (SELECT 1) INSERT INTO t SELECT * FROM x",
+		"with x as (select 1), y as (select 2) upsert into t select * from x",
+		"WITH x AS (SELECT 1)",
+		"SELECTED",
+		"/* SELECT */ DROP TABLE t",
+		"",
+	} {
+		require.False(t, DefaultIdempotent(stmt), stmt)
+	}
+}
+
+func TestRetryReconnectFails(t *testing.T) {
+	var logOut bytes.Buffer
+	reconnectErr := errors.New("connection refused")
+	conn := &Conn{log: log.New(&logOut, "", 0), opts: Options{Retry: &RetryPolicy{
+		MaxAttempts: 2,
+		Backoff:     time.Millisecond,
+		Reconnect: func(context.Context) (thrift.TTransport, *hive.Client, error) {
+			return nil, nil, reconnectErr
+		},
+	}}}
+	runErr := fmt.Errorf("%w: connection reset", driver.ErrBadConn)
+	runs := 0
+	err := conn.retry(context.Background(), "SELECT 1", func() error {
+		runs++
+		return runErr
+	})
+	require.Equal(t, 1, runs)
+	require.ErrorIs(t, err, runErr)
+	require.ErrorIs(t, err, reconnectErr)
+	require.NotContains(t, logOut.String(), "retried as")
+}
@@ -1,9 +1,12 @@
 package isql
 
 import (
+	"context"
 	"database/sql/driver"
+	"io"
 	"reflect"
 
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
 	"github.com/sclgo/impala-go/internal/hive"
 )
 
@@ -11,12 +14,19 @@ import (
 type Rows struct {
 	rs      *hive.ResultSet
 	schema  *hive.TableSchema
+	op      *hive.Operation
 	closefn func() error
+	// abortfn, if not nil, is called when fetching fails and returns the error for the caller
+	abortfn func(cause error) error
+	// script, if not nil, is the multi-statement query of the rows. scriptIndex is the index of their statement.
+	script      *script
+	scriptIndex int
 }
 
 // Close closes rows iterator. Implements [driver.Rows].
 func (r *Rows) Close() error {
-	return r.closefn()
+	r.rs.Close()
+	return withQueryID(r.closefn(), r.op)
 }
 
 // Columns returns the names of the columns. Implements [driver.Rows].
@@ -59,5 +69,79 @@ func (r *Rows) ColumnTypeLength(index int) (length int64, ok bool) {
 
 // Next prepares next row for scanning. Implements [driver.Rows].
 func (r *Rows) Next(dest []driver.Value) error {
-	return r.rs.Next(dest)
+	err := r.rs.Next(dest)
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return r.fetchErr(err)
+}
+
+// NextBatch returns the next rows in columnar form, without converting the values to driver.Value.
+// It returns io.EOF after the last row. See [hive.ResultSet.NextBatch].
+func (r *Rows) NextBatch() (*hive.Batch, error) {
+	batch, err := r.rs.NextBatch()
+	if err == nil || err == io.EOF {
+		return batch, err
+	}
+	return nil, r.fetchErr(err)
+}
+
+// fetchErr returns the error for the caller when fetching rows failed with err
+func (r *Rows) fetchErr(err error) error {
+	if r.abortfn != nil {
+		err = r.abortfn(err)
+	}
+	err = withQueryID(err, r.op)
+	if r.script != nil {
+		err = r.script.err(err, r.scriptIndex)
+	}
+	return err
+}
+
+// HasNextResultSet tells if statements of a multi-statement query remain to run.
+// Implements [driver.RowsNextResultSet].
+func (r *Rows) HasNextResultSet() bool {
+	return r.script != nil && r.script.hasNext()
+}
+
+// NextResultSet closes the current result set and runs the next statements of a multi-statement query
+// until one returns a result set. Returns io.EOF if none does. Implements [driver.RowsNextResultSet].
+func (r *Rows) NextResultSet() error {
+	if r.script == nil {
+		return io.EOF
+	}
+	err := r.Close()
+	// the rows must not close the finished operation again
+	r.closefn = func() error { return nil }
+	if err != nil {
+		return r.script.err(err, r.scriptIndex)
+	}
+	next, err := r.script.nextRows(false)
+	if err != nil {
+		return err
+	}
+	*r = *next
+	return nil
+}
+
+// QueryID returns the Impala query ID of the query in the hi:lo format that Impala shows
+func (r *Rows) QueryID() string {
+	return r.op.QueryID()
+}
+
+// QueryLog returns the operation log and the info messages of the query. The log isn't available
+// after the rows are closed.
+func (r *Rows) QueryLog(ctx context.Context) (string, []string, error) {
+	log, err := r.op.Log(ctx)
+	if err != nil {
+		return "", nil, mapErr(withQueryID(err, r.op))
+	}
+	return log, r.op.InfoMessages(), nil
+}
+
+// RuntimeProfile returns the runtime profile of the query. It can be called before or after Close,
+// but not concurrently with Next.
+func (r *Rows) RuntimeProfile(ctx context.Context, format runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error) {
+	profile, tree, err := r.op.RuntimeProfile(ctx, format)
+	return profile, tree, mapErr(withQueryID(err, r.op))
 }
@@ -0,0 +1,155 @@
+package isql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/sclgo/impala-go/internal/hive"
+)
+
+// StatementError is the error of a statement of a multi-statement script
+type StatementError struct {
+	err error
+	// Index is the 0-based index of the failed statement in the script
+	Index int
+	// Statement is the text of the failed statement
+	Statement string
+}
+
+func (e *StatementError) Error() string {
+	const maxText = 200
+	text := e.Statement
+	if len(text) > maxText {
+		text = strings.ToValidUTF8(text[:maxText], "") + "..."
+	}
+	return fmt.Sprintf("%v (statement %d: %s)", e.err, e.Index, text)
+}
+
+func (e *StatementError) Unwrap() error {
+	return e.err
+}
+
+// newStatementError returns err as the error of the statement at index. The errors of the statements after the first
+// must not be driver.ErrBadConn, because database/sql would retry the whole script on another connection,
+// running the earlier statements again.
+func newStatementError(err error, index int, stmt string) error {
+	if index > 0 && errors.Is(err, driver.ErrBadConn) {
+		err = fmt.Errorf("impala: connection lost in the middle of a script: %v", err)
+	}
+	return &StatementError{err: err, Index: index, Statement: stmt}
+}
+
+// splitScript returns the statements of a script if Options.MultiStatements is set, or nil otherwise
+func (c *Conn) splitScript(query string) []string {
+	if !c.opts.MultiStatements {
+		return nil
+	}
+	return splitStatements(query)
+}
+
+// execScript runs the statements one by one on the session. The result has the totals of all statements.
+func (c *Conn) execScript(ctx context.Context, session *hive.Session, stmts []string) (*Result, error) {
+	total := &Result{}
+	for i, stmt := range stmts {
+		res, err := c.exec(ctx, session, stmt)
+		if err != nil {
+			return nil, newStatementError(mapErr(err), i, stmt)
+		}
+		total.add(res)
+	}
+	return total, nil
+}
+
+// queryScript runs the statements one by one on the session, until one returns a result set.
+// The remaining statements run as the returned rows advance to the next result sets.
+func (c *Conn) queryScript(ctx context.Context, session *hive.Session, stmts []string) (driver.Rows, error) {
+	s := &script{conn: c, ctx: ctx, session: session, stmts: stmts}
+	rows, err := s.nextRows(true)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// script is a multi-statement query that is running
+type script struct {
+	conn    *Conn
+	ctx     context.Context
+	session *hive.Session
+	stmts   []string
+	// next is the index of the next statement to run
+	next int
+}
+
+// nextRows runs the next statements until one returns a result set and returns its rows. Statements without
+// result sets run to completion. If none of the statements has a result set, nextRows returns io.EOF,
+// or, if last is set, the rows of the last statement, which have no columns.
+func (s *script) nextRows(last bool) (*Rows, error) {
+	for s.next < len(s.stmts) {
+		index := s.next
+		s.next++
+		operation, err := s.conn.execute(s.ctx, s.session, s.stmts[index])
+		if err != nil {
+			return nil, s.err(mapErr(err), index)
+		}
+		if operation.HasResultSet() || (last && !s.hasNext()) {
+			rows, err := s.conn.rows(s.ctx, operation)
+			if err != nil {
+				return nil, s.err(mapErr(err), index)
+			}
+			rows.script = s
+			rows.scriptIndex = index
+			return rows, nil
+		}
+		if _, err = s.conn.finish(s.ctx, operation); err != nil {
+			return nil, s.err(mapErr(err), index)
+		}
+	}
+	return nil, io.EOF
+}
+
+// hasNext tells if statements remain to run
+func (s *script) hasNext() bool {
+	return s.next < len(s.stmts)
+}
+
+// err returns err as the error of the statement at index
+func (s *script) err(err error, index int) error {
+	return newStatementError(err, index, s.stmts[index])
+}
+
+// add adds the counts of other to r
+func (r *Result) add(other *Result) {
+	r.rowsAffected += other.rowsAffected
+	if other.DML == nil {
+		return
+	}
+	if r.DML == nil {
+		r.DML = &impalaservice.TDmlResult_{RowsModified: map[string]int64{}}
+	}
+	addCounts(&r.DML.RowsModified, other.DML.RowsModified)
+	addCounts(&r.DML.RowsDeleted, other.DML.RowsDeleted)
+	if other.DML.IsSetNumRowErrors() {
+		numRowErrors := r.DML.GetNumRowErrors() + other.DML.GetNumRowErrors()
+		r.DML.NumRowErrors = &numRowErrors
+	}
+}
+
+func addCounts(counts *map[string]int64, other map[string]int64) {
+	if len(other) == 0 {
+		return
+	}
+	if *counts == nil {
+		*counts = maps.Clone(other)
+		return
+	}
+	for key, count := range other {
+		(*counts)[key] += count
+	}
+}
@@ -3,16 +3,19 @@ package isql
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
 	"github.com/sclgo/impala-go/internal/hive"
 )
 
 // Stmt is statement
 type Stmt struct {
-	stmt string
+	stmt *statement
 
 	conn *Conn
 }
@@ -22,13 +25,9 @@ func (s *Stmt) Close() error {
 	return nil
 }
 
-// NumInput returns number of inputs
+// NumInput returns number of inputs, so database/sql checks the number of arguments
 func (s *Stmt) NumInput() int {
-	// -1 means the driver doesn't know how to count the number of
-	// placeholders, so (database/sql) won't sanity check input
-	// See https://cs.opensource.google/go/go/+/refs/tags/go1.23.4:src/database/sql/convert.go;l=109
-	// We could implement counting placeholders in the future.
-	return -1
+	return s.stmt.numInput()
 }
 
 // Stmt does not need to implement https://pkg.go.dev/database/sql/driver#NamedValueChecker
@@ -50,136 +49,325 @@ func (s *Stmt) Query([]driver.Value) (driver.Rows, error) {
 
 // QueryContext executes a query that may return rows
 func (s *Stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	return s.conn.QueryContext(ctx, s.stmt, args)
+	return s.conn.queryStatement(ctx, s.stmt, args)
 }
 
 // ExecContext executes a query that doesn't return rows
 func (s *Stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	return s.conn.ExecContext(ctx, s.stmt, args)
+	return s.conn.execStatement(ctx, s.stmt, args)
 }
 
-// template replaces all ? placeholders with ordinal placeholders
-// Supports for ? placeholders mirrors the Hive and Impala JDBC drivers,
-// providing compatibility with them.
-// '?' inside string literals or identifiers are allowed and are not placeholders.
-func template(query string) string {
-	if !strings.Contains(query, "?") {
-		return query
-	}
+// statement is a query split at its placeholders
+type statement struct {
+	query        string
+	placeholders []placeholder
+}
 
+// placeholder is a ? or @name placeholder at query[start:end]
+type placeholder struct {
+	start, end int
+	// key is the name of the argument of the placeholder. It is "pN" for the Nth ? placeholder,
+	// so @pN placeholders and ? placeholders can be used together.
+	key string
+}
+
+// parseStatement finds the placeholders in query in a single pass, skipping string literals,
+// quoted identifiers and comments. Support for ? placeholders mirrors the Hive and Impala JDBC drivers,
+// providing compatibility with them. @name placeholders are for named arguments.
+func parseStatement(query string) *statement {
 	// Docs:https://impala.apache.org/docs/build/html/topics/impala_literals.html
 	// https://impala.apache.org/docs/build/html/topics/impala_identifiers.html
+	// https://impala.apache.org/docs/build/html/topics/impala_comments.html
 	// JDBC impl:https://github.com/apache/hive/blob/83d98f42fc7/jdbc/src/java/org/apache/hive/jdbc/HivePreparedStatement.java#L141
-
-	var sb strings.Builder
-	sb.Grow(len(query))
+	stmt := &statement{query: query}
+	if !strings.ContainsAny(query, "?@") {
+		return stmt
+	}
 	ordinal := 1
-	cntQuote := 0
-	cntDQuote := 0
-	cntBacktick := 0
-	for i := 0; i < len(query); i++ {
-		c := query[i]
-		if c == '\\' {
-			sb.WriteByte(c)
-			if i+1 < len(query) {
-				sb.WriteByte(query[i+1])
-				i++
-			}
+	for i := 0; i < len(query); {
+		if end := skipNonCode(query, i); end > i {
+			i = end
 			continue
 		}
-
-		var replaced bool
-		switch c {
-		case '"':
-			if cntQuote%2 == 0 && cntBacktick%2 == 0 {
-				cntDQuote++
-			}
-		case '\'':
-			if cntDQuote%2 == 0 && cntBacktick%2 == 0 {
-				cntQuote++
+		switch query[i] {
+		case '?':
+			stmt.placeholders = append(stmt.placeholders, placeholder{start: i, end: i + 1, key: fmt.Sprintf("p%d", ordinal)})
+			ordinal++
+			i++
+		case '@':
+			end := i + 1
+			for end < len(query) && isWordByte(query[end]) {
+				end++
 			}
-		case '`':
-			if cntQuote%2 == 0 && cntDQuote%2 == 0 {
-				cntBacktick++
+			if end > i+1 {
+				stmt.placeholders = append(stmt.placeholders, placeholder{start: i, end: end, key: query[i+1 : end]})
 			}
-		case '?':
-			if cntQuote%2 == 0 && cntDQuote%2 == 0 && cntBacktick%2 == 0 {
-				// Fprintf to strings.Builder can't return err
-				_, _ = fmt.Fprintf(&sb, "@p%d", ordinal)
-				ordinal++
-				replaced = true
+			i = end
+		default:
+			i++
+		}
+	}
+	return stmt
+}
+
+// splitStatements splits a script at the semicolons outside string literals, quoted identifiers and comments.
+// The statements are trimmed. Statements that have only whitespace and comments are dropped.
+func splitStatements(script string) []string {
+	var stmts []string
+	start := 0
+	hasCode := false
+	for i := 0; i <= len(script); {
+		if i == len(script) || script[i] == ';' {
+			if hasCode {
+				stmts = append(stmts, strings.TrimSpace(script[start:i]))
 			}
+			i++
+			start = i
+			hasCode = false
+			continue
+		}
+		end := skipNonCode(script, i)
+		if end == i {
+			end++
 		}
-		if !replaced {
-			sb.WriteByte(c)
+		if !isSpaceOrComment(script[i:end]) {
+			hasCode = true
 		}
+		i = end
+	}
+	return stmts
+}
+
+func isSpaceOrComment(s string) bool {
+	return strings.TrimSpace(s) == "" || strings.HasPrefix(s, "--") || strings.HasPrefix(s, "/*")
+}
+
+// skipNonCode returns the index after the string literal, quoted identifier, comment or escaped character
+// that starts at query[i], or i if none starts there
+func skipNonCode(query string, i int) int {
+	switch c := query[i]; {
+	case c == '\\':
+		// an escaped character, like \? , is not a placeholder
+		return min(i+2, len(query))
+	case c == '\'' || c == '"':
+		return skipQuoted(query, i, c, true)
+	case c == '`':
+		return skipQuoted(query, i, c, false)
+	case strings.HasPrefix(query[i:], "--"):
+		if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+			return i + end + 1
+		}
+		return len(query)
+	case strings.HasPrefix(query[i:], "/*"):
+		if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+			return i + end + 4
+		}
+		return len(query)
+	}
+	return i
+}
+
+// skipQuoted returns the index after the literal or identifier, quoted with quote, that starts at start
+func skipQuoted(query string, start int, quote byte, escapes bool) int {
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// numInput returns the number of distinct arguments that the placeholders refer to
+func (s *statement) numInput() int {
+	keys := make(map[string]bool, len(s.placeholders))
+	for _, p := range s.placeholders {
+		keys[p.key] = true
+	}
+	return len(keys)
+}
+
+// argKey returns the placeholder key of arg
+func argKey(arg driver.NamedValue) string {
+	if arg.Name != "" {
+		return arg.Name
 	}
-	return sb.String()
+	return fmt.Sprintf("p%d", arg.Ordinal)
 }
 
-func statement(tmpl string, args []driver.NamedValue) string {
-	stmt := tmpl
+// bind replaces the placeholders with the literals of the args. Each placeholder must have an arg
+// and each arg must be used by a placeholder.
+func (s *statement) bind(args []driver.NamedValue) (string, error) {
+	if len(args) == 0 && len(s.placeholders) == 0 {
+		return s.query, nil
+	}
+	literals := make(map[string]string, len(args))
+	used := make(map[string]bool, len(args))
 	for _, arg := range args {
-		var re *regexp.Regexp
-		if arg.Name != "" {
-			re = regexp.MustCompile(fmt.Sprintf("@%s%s", arg.Name, `\b`))
-		} else {
-			re = regexp.MustCompile(fmt.Sprintf("@p%d%s", arg.Ordinal, `\b`))
+		key := argKey(arg)
+		lit, err := literal(arg.Value)
+		if err != nil {
+			return "", fmt.Errorf("argument @%s: %w", key, err)
 		}
-		formatStr := "%v"
-		if _, ok := arg.Value.(string); ok {
-			formatStr = "'%v'"
+		literals[key] = lit
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s.query))
+	last := 0
+	for _, p := range s.placeholders {
+		lit, ok := literals[p.key]
+		if !ok {
+			return "", fmt.Errorf("no argument for placeholder %s at offset %d", s.query[p.start:p.end], p.start)
 		}
-		val := fmt.Sprintf(formatStr, arg.Value)
-		stmt = re.ReplaceAllString(stmt, val)
+		used[p.key] = true
+		sb.WriteString(s.query[last:p.start])
+		sb.WriteString(lit)
+		last = p.end
 	}
-	return stmt
+	sb.WriteString(s.query[last:])
+
+	for _, arg := range args {
+		if key := argKey(arg); !used[key] {
+			return "", fmt.Errorf("argument @%s is not used by any placeholder", key)
+		}
+	}
+	return sb.String(), nil
 }
 
-func query(ctx context.Context, session *hive.Session, stmt string) (driver.Rows, error) {
-	operation, err := session.ExecuteStatement(ctx, stmt)
+func (c *Conn) query(ctx context.Context, session *hive.Session, stmt string) (*Rows, error) {
+	operation, err := c.execute(ctx, session, stmt)
 	if err != nil {
 		return nil, err
 	}
+	return c.rows(ctx, operation)
+}
 
-	schema, err := operation.GetResultSetMetadata(ctx)
+// execute starts stmt on the session
+func (c *Conn) execute(ctx context.Context, session *hive.Session, stmt string) (*hive.Operation, error) {
+	operation, err := session.ExecuteStatement(ctx, stmt, queryOptions(ctx))
 	if err != nil {
 		return nil, err
 	}
+	c.notifyQueryID(ctx, operation)
+	c.trackProgress(ctx, operation)
+	return operation, nil
+}
+
+// rows returns the rows of the started operation. Rows.Next waits for the operation to finish.
+func (c *Conn) rows(ctx context.Context, operation *hive.Operation) (*Rows, error) {
+	schema, err := operation.GetResultSetMetadata(ctx)
+	if err != nil {
+		return nil, c.closeAfterErr(ctx, operation, err)
+	}
 
 	rs, err := operation.FetchResults(ctx, schema)
 	if err != nil {
-		return nil, err
+		return nil, c.closeAfterErr(ctx, operation, err)
 	}
 
 	return &Rows{
 		rs:     rs,
 		schema: schema,
+		op:     operation,
+		abortfn: func(cause error) error {
+			if ctx.Err() == nil {
+				return c.attachProfile(ctx, operation, cause)
+			}
+			return operation.Abort(ctx, cause)
+		},
 		// TODO align context handling with database/sql practices (Github #14)
 		closefn: func() error {
+			if ctx.Err() != nil {
+				// database/sql closes the rows when the context is done. Abort logs if the cancellation failed.
+				_ = operation.Abort(ctx, ctx.Err())
+				return nil
+			}
+			c.reportQueryLog(ctx, operation)
 			_, err := operation.Close(ctx)
 			return err
 		},
 	}, nil
 }
 
-func exec(ctx context.Context, session *hive.Session, stmt string) (driver.Result, error) {
-	operation, err := session.ExecuteStatement(ctx, stmt)
+func (c *Conn) exec(ctx context.Context, session *hive.Session, stmt string) (*Result, error) {
+	operation, err := c.execute(ctx, session, stmt)
 	if err != nil {
 		return nil, err
 	}
+	return c.finish(ctx, operation)
+}
 
+// finish waits for the started operation to finish and closes it
+func (c *Conn) finish(ctx context.Context, operation *hive.Operation) (*Result, error) {
 	// wait for DDL/DML to finish like impala-shell :
 	// https://github.com/apache/impala/blob/aac375e/shell/impala_shell.py#L1412
-	err = operation.WaitToFinish(ctx)
+	err := operation.WaitToFinish(ctx)
 	if err != nil {
-		return nil, err
+		return nil, c.closeAfterErr(ctx, operation, err)
 	}
 
+	c.reportQueryLog(ctx, operation)
 	rowsAffected, err := operation.Close(ctx)
 	if err != nil {
-		return nil, err
+		return nil, withQueryID(err, operation)
 	}
 
-	return driver.RowsAffected(rowsAffected), nil
+	dml := operation.DMLResult()
+	if c.opts.FailOnRowErrors && dml != nil && dml.GetNumRowErrors() > 0 {
+		return nil, withQueryID(&RowErrorsError{Result: dml}, operation)
+	}
+	return &Result{rowsAffected: rowsAffected, DML: dml}, nil
+}
+
+// Result is the result of a statement run by ExecContext. Implements [driver.Result].
+type Result struct {
+	rowsAffected int64
+	// DML is the detailed result of a DML statement or nil for other statements
+	DML *impalaservice.TDmlResult_
+}
+
+// LastInsertId is not supported. Implements [driver.Result].
+func (r *Result) LastInsertId() (int64, error) {
+	return driver.RowsAffected(r.rowsAffected).LastInsertId()
+}
+
+// RowsAffected returns the number of modified and deleted rows. Implements [driver.Result].
+func (r *Result) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+// closeAfterErr releases the operation after err. If ctx is done, the operation may still be running,
+// so it is cancelled on the server too. The returned error has the query ID attached.
+func (c *Conn) closeAfterErr(ctx context.Context, operation *hive.Operation, err error) error {
+	if ctx.Err() != nil {
+		return withQueryID(operation.Abort(ctx, err), operation)
+	}
+	err = c.attachProfile(ctx, operation, err)
+	c.reportQueryLog(ctx, operation)
+	_, _ = operation.Close(ctx)
+	return withQueryID(err, operation)
+}
+
+// attachProfile returns err with the runtime profile of the failed operation attached if Options.ProfileOnError is set.
+// If the profile can't be retrieved, err is returned as is.
+func (c *Conn) attachProfile(ctx context.Context, operation *hive.Operation, err error) error {
+	var tErr thrift.TTransportException
+	if !c.opts.ProfileOnError || errors.As(err, &tErr) {
+		return err
+	}
+	profile, _, profileErr := operation.RuntimeProfile(ctx, runtimeprofile.TRuntimeProfileFormat_STRING)
+	if profileErr != nil {
+		c.log.Printf("failed to get the runtime profile of failed query %s: %v", operation.QueryID(), profileErr)
+		return err
+	}
+	return &ProfileError{err: err, Profile: profile}
 }
@@ -2,6 +2,7 @@ package isql
 
 import (
 	"database/sql/driver"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,13 +22,21 @@ func TestStatement_ValueReplacement(t *testing.T) {
 			target: "'val_1' p1",
 		},
 		{
-			stmt: "@p1 @p10 @p11 @named @named1 @p1",
+			stmt: "@p1 @p11 @named @p1",
 			args: []driver.NamedValue{
 				driver.NamedValue{Ordinal: 1, Value: "val_1"},
 				driver.NamedValue{Ordinal: 10, Name: "named", Value: "val_named"},
 				driver.NamedValue{Ordinal: 11, Value: "val_11"},
 			},
-			target: "'val_1' @p10 'val_11' 'val_named' @named1 'val_1'",
+			target: "'val_1' 'val_11' 'val_named' 'val_1'",
+		},
+		{
+			stmt: "? '@p1 ?' `?` -- ? @p1\n /* ?@p1 */ ? @p2",
+			args: []driver.NamedValue{
+				driver.NamedValue{Ordinal: 1, Value: 1},
+				driver.NamedValue{Ordinal: 2, Value: 2},
+			},
+			target: "1 '@p1 ?' `?` -- ? @p1\n /* ?@p1 */ 2 2",
 		},
 		{
 			stmt: "@p1 @p2",
@@ -37,15 +46,24 @@ func TestStatement_ValueReplacement(t *testing.T) {
 			},
 			target: "'1' 2",
 		},
+		{
+			stmt: "@p1 @p2",
+			args: []driver.NamedValue{
+				driver.NamedValue{Ordinal: 1, Value: "@p2 $1"},
+				driver.NamedValue{Ordinal: 2, Value: "'"},
+			},
+			target: `'@p2 $1' '\''`,
+		},
 	}
 
 	for _, tt := range tests {
-		result := statement(tt.stmt, tt.args)
+		result, err := parseStatement(tt.stmt).bind(tt.args)
+		require.NoError(t, err)
 		require.Equal(t, tt.target, result)
 	}
 }
 
-func TestTemplate(t *testing.T) {
+func TestParseStatement(t *testing.T) {
 	tests := []struct {
 		stmt   string
 		target string
@@ -78,10 +96,86 @@ func TestTemplate(t *testing.T) {
 			stmt:   "`columnname?`",
 			target: "`columnname?`",
 		},
+		{
+			stmt:   "? -- ?\n? /* ? */ ?",
+			target: "@p1 -- ?\n@p2 /* ? */ @p3",
+		},
+		{
+			stmt:   "'unterminated ?",
+			target: "'unterminated ?",
+		},
 	}
 
 	for _, tt := range tests {
-		result := template(tt.stmt)
-		require.Equal(t, tt.target, result)
+		require.Equal(t, tt.target, render(parseStatement(tt.stmt)))
+	}
+}
+
+// render replaces the placeholders of stmt with @key
+func render(stmt *statement) string {
+	var sb strings.Builder
+	last := 0
+	for _, p := range stmt.placeholders {
+		sb.WriteString(stmt.query[last:p.start])
+		sb.WriteString("@" + p.key)
+		last = p.end
+	}
+	sb.WriteString(stmt.query[last:])
+	return sb.String()
+}
+
+func TestStatement_Mismatch(t *testing.T) {
+	args := func(values ...any) []driver.NamedValue {
+		var named []driver.NamedValue
+		for i, v := range values {
+			named = append(named, driver.NamedValue{Ordinal: i + 1, Value: v})
+		}
+		return named
+	}
+
+	_, err := parseStatement("SELECT ?, ?").bind(args(1))
+	require.ErrorContains(t, err, "no argument for placeholder ? at offset 10")
+	_, err = parseStatement("SELECT ?").bind(args(1, 2))
+	require.ErrorContains(t, err, "argument @p2 is not used")
+	_, err = parseStatement("SELECT @name").bind(nil)
+	require.ErrorContains(t, err, "no argument for placeholder @name")
+	_, err = parseStatement("SELECT 1").bind([]driver.NamedValue{{Ordinal: 1, Name: "name", Value: 1}})
+	require.ErrorContains(t, err, "argument @name is not used")
+	_, err = parseStatement("SELECT '?' -- ?").bind(args(1))
+	require.ErrorContains(t, err, "argument @p1 is not used")
+}
+
+func TestStmt_NumInput(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		numInput int
+	}{
+		{stmt: "SELECT 1", numInput: 0},
+		{stmt: "SELECT ?, ? /* ? */", numInput: 2},
+		{stmt: "SELECT @a, @b, @a, '@c' -- @d", numInput: 2},
+		{stmt: "SELECT ?, @p1, `@p2`", numInput: 1},
+		{stmt: "SELECT @, \\?", numInput: 0},
+	}
+	for _, tt := range tests {
+		stmt := &Stmt{stmt: parseStatement(tt.stmt)}
+		require.Equal(t, tt.numInput, stmt.NumInput(), tt.stmt)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		script string
+		stmts  []string
+	}{
+		{script: "SELECT 1", stmts: []string{"SELECT 1"}},
+		{script: " SELECT 1 ;\n", stmts: []string{"SELECT 1"}},
+		{script: "SET a=1; SELECT ';', \";\", `;`; SELECT 2", stmts: []string{"SET a=1", "SELECT ';', \";\", `;`", "SELECT 2"}},
+		{script: "SELECT 'it\\'s;'; SELECT 2", stmts: []string{"SELECT 'it\\'s;'", "SELECT 2"}},
+		{script: "SELECT 1 -- a; b\n; /* c; d */ SELECT 2", stmts: []string{"SELECT 1 -- a; b", "/* c; d */ SELECT 2"}},
+		{script: "SELECT 1;; -- end;\n/* end; */", stmts: []string{"SELECT 1"}},
+		{script: " ; -- only comments", stmts: nil},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.stmts, splitStatements(tt.script), tt.script)
 	}
 }
@@ -2,10 +2,32 @@ package sasl
 
 import (
 	"fmt"
+	"sync"
 )
 
-var supported = map[string]func(*Options) mech{
-	MechPlain: newPlain,
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]MechanismFactory{
+		MechPlain: newPlain,
+	}
+)
+
+// Register makes a mechanism available by name, replacing any mechanism registered with the same name.
+func Register(name string, factory MechanismFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// IsRegistered reports if a mechanism is registered with the given name
+func IsRegistered(name string) bool {
+	return lookup(name) != nil
+}
+
+func lookup(name string) MechanismFactory {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return registry[name]
 }
 
 // NewClient created new sasl client
@@ -17,9 +39,14 @@ func NewClient(opts *Options) Client {
 
 func (c *client) Start(mechlist []string) (string, []byte, bool, error) {
 	for _, mech := range mechlist {
-		if newMech, ok := supported[mech]; ok {
-			c.m = newMech(c.opts)
-			return c.m.Start()
+		if newMech := lookup(mech); newMech != nil {
+			m, err := newMech(c.opts)
+			if err != nil {
+				return "", nil, false, fmt.Errorf("sasl: failed to create mech %s: %w", mech, err)
+			}
+			c.m = m
+			initial, done, err := c.m.Start()
+			return mech, initial, done, err
 		}
 	}
 	return "", nil, false, fmt.Errorf("sasl: none of the provided mechs %v are supported", mechlist)
@@ -29,19 +56,33 @@ func (c *client) Step(challenge []byte) ([]byte, bool, error) {
 	return c.m.Step(challenge)
 }
 
-func (c *client) InterpretReceiveEOF(transportError error) error {
-	return c.m.InterpretReceiveEOF(transportError)
+func (c *client) QOP() QOP {
+	return c.m.QOP()
 }
 
-func (c *client) Free() {}
+func (c *client) Wrap(payload []byte) ([]byte, error) {
+	return c.m.Wrap(payload)
+}
+
+func (c *client) Unwrap(payload []byte) ([]byte, error) {
+	return c.m.Unwrap(payload)
+}
 
-type mech interface {
-	Start() (mech string, initial []byte, done bool, err error)
-	Step(challenge []byte) (response []byte, done bool, err error)
-	InterpretReceiveEOF(transportError error) error
+func (c *client) InterpretReceiveEOF(transportError error) error {
+	// The server closes the connection when authentication fails.
+	username := c.opts.Username
+	if username == "" {
+		username = c.opts.Principal
+	}
+	return &AuthError{
+		username:       username,
+		transportError: transportError,
+	}
 }
 
+func (c *client) Free() {}
+
 type client struct {
-	m    mech
+	m    Mechanism
 	opts *Options
 }
@@ -4,22 +4,27 @@ type plain struct {
 	opts *Options
 }
 
-func newPlain(opts *Options) mech {
-	return &plain{opts: opts}
+func newPlain(opts *Options) (Mechanism, error) {
+	return &plain{opts: opts}, nil
 }
 
-func (m *plain) Start() (string, []byte, bool, error) {
+func (m *plain) Start() ([]byte, bool, error) {
 	initial := []byte(m.opts.Username + "\x00" + m.opts.Username + "\x00" + m.opts.Password)
-	return MechPlain, initial, true, nil
+	return initial, true, nil
 }
 
 func (m *plain) Step(_ []byte) ([]byte, bool, error) {
 	return nil, false, ErrUnexpectedServerChallenge
 }
 
-func (m *plain) InterpretReceiveEOF(transportError error) error {
-	return &AuthError{
-		username:       m.opts.Username,
-		transportError: transportError,
-	}
+func (m *plain) QOP() QOP {
+	return QOPAuth
+}
+
+func (m *plain) Wrap(payload []byte) ([]byte, error) {
+	return payload, nil
+}
+
+func (m *plain) Unwrap(payload []byte) ([]byte, error) {
+	return payload, nil
 }
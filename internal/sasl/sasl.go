@@ -15,21 +15,62 @@ type Mech string
 
 // SASL mechanism tokens
 const (
-	MechPlain = "PLAIN"
+	MechPlain  = "PLAIN"
+	MechGSSAPI = "GSSAPI"
+)
+
+// QOP is SASL quality of protection. Values are bit flags, as in the GSSAPI security layer negotiation (RFC 4752).
+type QOP byte
+
+// Quality of protection values
+const (
+	// QOPAuth is authentication only. Messages are not wrapped.
+	QOPAuth QOP = 1
+	// QOPAuthInt is authentication with integrity protection
+	QOPAuthInt QOP = 2
+	// QOPAuthConf is authentication with integrity and confidentiality protection
+	QOPAuthConf QOP = 4
 )
 
 // Options contains data related to SASL negotiation
 type Options struct {
-	Service  string
-	Host     string
-	Username string
-	Password string
+	// Mechanism is the name of the registered mechanism to use
+	Mechanism string
+	// Service is the service name in the server principal e.g. impala
+	Service string
+	// Host is the server hostname
+	Host string
+	// Principal is the client principal for mechanisms like GSSAPI. May be empty.
+	Principal string
+	Username  string
+	Password  string
 }
 
+// Mechanism is a client-side SASL mechanism. A new instance is used for each connection.
+// The instance is not used concurrently.
+type Mechanism interface {
+	// Start returns the initial response. done reports if the mechanism expects no further challenges.
+	Start() (initial []byte, done bool, err error)
+	// Step returns the response to a server challenge.
+	Step(challenge []byte) (response []byte, done bool, err error)
+	// QOP returns the negotiated quality of protection. It is called after successful negotiation.
+	QOP() QOP
+	// Wrap protects an outgoing message. It is called only if QOP is not QOPAuth.
+	Wrap(payload []byte) ([]byte, error)
+	// Unwrap verifies and decodes an incoming message. It is called only if QOP is not QOPAuth.
+	Unwrap(payload []byte) ([]byte, error)
+}
+
+// MechanismFactory creates a mechanism instance for a single connection
+type MechanismFactory func(opts *Options) (Mechanism, error)
+
 // Client is SASL client
 type Client interface {
 	Start(mechlist []string) (mech string, initial []byte, done bool, err error)
 	Step(challenge []byte) (response []byte, done bool, err error)
+	QOP() QOP
+	Wrap(payload []byte) ([]byte, error)
+	Unwrap(payload []byte) ([]byte, error)
 	Free()
 	InterpretReceiveEOF(transportError error) error
 }
@@ -36,6 +36,9 @@ type TSaslTransport struct {
 
 	trans thrift.TTransport
 	sasl  Client
+	mech  string
+	// wrap is true if messages are protected (wrapped) after negotiation
+	wrap bool
 }
 
 // Status is SASL negotiation status
@@ -51,11 +54,20 @@ const (
 )
 
 func NewTSaslTransport(t thrift.TTransport, opts *Options) (*TSaslTransport, error) {
+	mech := opts.Mechanism
+	if mech == "" {
+		mech = MechPlain
+	}
+	if !IsRegistered(mech) {
+		return nil, fmt.Errorf("sasl: mechanism %s is not registered", mech)
+	}
+
 	sasl := NewClient(opts)
 
 	return &TSaslTransport{
 		trans: t,
 		sasl:  sasl,
+		mech:  mech,
 
 		rbuf: bytes.NewBuffer(nil),
 		wbuf: bytes.NewBuffer(nil),
@@ -74,41 +86,50 @@ func (t *TSaslTransport) Open() error {
 		}
 	}
 
-	mech, initial, _, err := t.sasl.Start([]string{MechPlain})
+	mech, initial, done, err := t.sasl.Start([]string{t.mech})
 	if err != nil {
 		return err
 	}
 
 	if err := t.negotiationSend(StatusStart, []byte(mech)); err != nil {
-return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
+		return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
 	}
 	if err := t.negotiationSend(StatusOK, initial); err != nil {
-return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
+		return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
 	}
 
 	for {
 		status, challenge, err := t.receive()
 		if err != nil {
-return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
+			return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
 		}
 
 		if status != StatusOK && status != StatusComplete {
-			return fmt.Errorf("sasl: negotiation failed. bad status: %d", status)
+			// for BAD and ERROR statuses, the body is the reason that the server gives
+			return fmt.Errorf("sasl: negotiation failed. bad status: %d: %s", status, challenge)
 		}
 
 		if status == StatusComplete {
+			if !done && len(challenge) > 0 {
+				// the server may send its final token together with the completion status
+				if _, _, err := t.sasl.Step(challenge); err != nil {
+					return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
+				}
+			}
 			break
 		}
 
-		payload, _, err := t.sasl.Step(challenge)
+		var payload []byte
+		payload, done, err = t.sasl.Step(challenge)
 		if err != nil {
-return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
+			return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
 		}
 		if err := t.negotiationSend(StatusOK, payload); err != nil {
-return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
+			return fmt.Errorf("sasl: negotiation failed for mech %s. %w", mech, err)
 		}
 
 	}
+	t.wrap = t.sasl.QOP() != QOPAuth
 	return nil
 
 }
@@ -126,7 +147,7 @@ func (t *TSaslTransport) Read(buf []byte) (int, error) {
 
 func (t *TSaslTransport) readFrame(buf []byte) (int, error) {
 	header := make([]byte, 4)
-	_, err := t.trans.Read(header)
+	_, err := io.ReadFull(t.trans, header)
 	if err != nil {
 		return 0, err
 	}
@@ -138,6 +159,12 @@ func (t *TSaslTransport) readFrame(buf []byte) (int, error) {
 	if err != nil {
 		return 0, err
 	}
+	if t.wrap {
+		body, err = t.sasl.Unwrap(body)
+		if err != nil {
+			return 0, fmt.Errorf("sasl: failed to unwrap frame: %w", err)
+		}
+	}
 	t.rbuf = bytes.NewBuffer(body)
 	return t.rbuf.Read(buf)
 }
@@ -152,6 +179,12 @@ func (t *TSaslTransport) Flush(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if t.wrap {
+		in, err = t.sasl.Wrap(in)
+		if err != nil {
+			return fmt.Errorf("sasl: failed to wrap frame: %w", err)
+		}
+	}
 
 	v := len(in)
 	var payload []byte
@@ -200,7 +233,7 @@ func (t *TSaslTransport) negotiationSend(status Status, body []byte) error {
 
 func (t *TSaslTransport) receive() (Status, []byte, error) {
 	header := make([]byte, 5)
-	_, err := t.trans.Read(header)
+	_, err := io.ReadFull(t.trans, header)
 	if err != nil {
 		var transportError thrift.TTransportException
 		if errors.As(err, &transportError) {
@@ -210,5 +243,9 @@ func (t *TSaslTransport) receive() (Status, []byte, error) {
 		}
 		return 0, nil, err
 	}
-	return Status(header[0]), header[1:], nil
+	body := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	if _, err = io.ReadFull(t.trans, body); err != nil {
+		return 0, nil, err
+	}
+	return Status(header[0]), body, nil
 }
@@ -0,0 +1,187 @@
+package sasl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTSaslTransport(t *testing.T) {
+	const mechName = "SCRIPTED"
+	Register(mechName, func(opts *Options) (Mechanism, error) {
+		return &scriptedMech{
+			challenges: []string{"challenge-1"},
+			responses:  []string{"response-1"},
+			qop:        QOPAuthInt,
+		}, nil
+	})
+
+	t.Run("negotiate and wrap", func(t *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer func() { _ = clientConn.Close() }()
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- runScriptedServer(serverConn)
+		}()
+
+		trans, err := NewTSaslTransport(thrift.NewTSocketFromConnConf(clientConn, nil), &Options{Mechanism: mechName})
+		require.NoError(t, err)
+		require.NoError(t, trans.Open())
+
+		_, err = trans.Write([]byte("ping"))
+		require.NoError(t, err)
+		require.NoError(t, trans.Flush(context.Background()))
+
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(trans, buf)
+		require.NoError(t, err)
+		require.Equal(t, "ping", string(buf))
+		require.NoError(t, <-serverErr)
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		_, err := NewTSaslTransport(nil, &Options{Mechanism: "NOSUCHMECH"})
+		require.ErrorContains(t, err, "not registered")
+	})
+
+	t.Run("server error message", func(t *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		defer func() { _ = clientConn.Close() }()
+		go func() {
+			_, _, _ = readNegotiation(serverConn)
+			_, _, _ = readNegotiation(serverConn)
+			_ = writeNegotiation(serverConn, StatusBad, "Error validating the login")
+		}()
+		trans, err := NewTSaslTransport(thrift.NewTSocketFromConnConf(clientConn, nil), &Options{Username: "fry"})
+		require.NoError(t, err)
+		require.ErrorContains(t, trans.Open(), "bad status: 3: Error validating the login")
+	})
+
+	t.Run("auth error on EOF", func(t *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		go func() {
+			// consume start and initial response, then reject by closing the connection
+			_, _, _ = readNegotiation(serverConn)
+			_, _, _ = readNegotiation(serverConn)
+			_ = serverConn.Close()
+		}()
+		trans, err := NewTSaslTransport(thrift.NewTSocketFromConnConf(clientConn, nil), &Options{Username: "fry"})
+		require.NoError(t, err)
+		err = trans.Open()
+		var authErr *AuthError
+		require.ErrorAs(t, err, &authErr)
+		require.ErrorContains(t, err, "fry")
+	})
+}
+
+// scriptedMech expects the given challenges in order and replies with the corresponding responses.
+// It wraps messages by prefixing them with "wrapped:".
+type scriptedMech struct {
+	challenges []string
+	responses  []string
+	step       int
+	qop        QOP
+}
+
+func (m *scriptedMech) Start() ([]byte, bool, error) {
+	return []byte("initial"), false, nil
+}
+
+func (m *scriptedMech) Step(challenge []byte) ([]byte, bool, error) {
+	if m.step >= len(m.challenges) || string(challenge) != m.challenges[m.step] {
+		return nil, false, ErrUnexpectedServerChallenge
+	}
+	resp := m.responses[m.step]
+	m.step++
+	return []byte(resp), m.step == len(m.challenges), nil
+}
+
+func (m *scriptedMech) QOP() QOP {
+	return m.qop
+}
+
+func (m *scriptedMech) Wrap(payload []byte) ([]byte, error) {
+	return append([]byte("wrapped:"), payload...), nil
+}
+
+func (m *scriptedMech) Unwrap(payload []byte) ([]byte, error) {
+	res, ok := bytes.CutPrefix(payload, []byte("wrapped:"))
+	if !ok {
+		return nil, errors.New("not wrapped")
+	}
+	return res, nil
+}
+
+func runScriptedServer(conn net.Conn) error {
+	expect := []struct {
+		status Status
+		body   string
+	}{
+		{StatusStart, "SCRIPTED"},
+		{StatusOK, "initial"},
+	}
+	for _, e := range expect {
+		if err := expectNegotiation(conn, e.status, e.body); err != nil {
+			return err
+		}
+	}
+	if err := writeNegotiation(conn, StatusOK, "challenge-1"); err != nil {
+		return err
+	}
+	if err := expectNegotiation(conn, StatusOK, "response-1"); err != nil {
+		return err
+	}
+	if err := writeNegotiation(conn, StatusComplete, ""); err != nil {
+		return err
+	}
+
+	// echo one data frame
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	frame := make([]byte, binary.BigEndian.Uint32(header))
+	if _, err := io.ReadFull(conn, frame); err != nil {
+		return err
+	}
+	if !bytes.Equal(frame, []byte("wrapped:ping")) {
+		return errors.New("unexpected frame: " + string(frame))
+	}
+	_, err := conn.Write(append(header, frame...))
+	return err
+}
+
+func expectNegotiation(conn net.Conn, status Status, body string) error {
+	gotStatus, gotBody, err := readNegotiation(conn)
+	if err != nil {
+		return err
+	}
+	if gotStatus != status || string(gotBody) != body {
+		return errors.New("unexpected negotiation message: " + string(gotBody))
+	}
+	return nil
+}
+
+func readNegotiation(conn net.Conn) (Status, []byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return 0, nil, err
+	}
+	body := make([]byte, binary.BigEndian.Uint32(header[1:]))
+	_, err := io.ReadFull(conn, body)
+	return Status(header[0]), body, err
+}
+
+func writeNegotiation(conn net.Conn, status Status, body string) error {
+	msg := []byte{byte(status), 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(msg[1:], uint32(len(body)))
+	_, err := conn.Write(append(msg, body...))
+	return err
+}
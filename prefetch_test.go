@@ -0,0 +1,153 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func startPrefetch(tb testing.TB, fake *fakeHS2, batchSize int, prefetch int) *sql.DB {
+	srv := httptest.NewServer(fake.httpHandler())
+	tb.Cleanup(srv.Close)
+	opts := httpTestOptions(tb, srv.URL)
+	opts.BatchSize = batchSize
+	opts.Prefetch = prefetch
+	db := sql.OpenDB(NewConnector(opts))
+	tb.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestPrefetch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all rows in order", func(t *testing.T) {
+		fake := &fakeHS2{batches: 5}
+		db := startPrefetch(t, fake, 3, 2)
+		rows, err := db.QueryContext(ctx, "SELECT x FROM t")
+		require.NoError(t, err)
+		var values []int
+		for rows.Next() {
+			var x int
+			require.NoError(t, rows.Scan(&x))
+			values = append(values, x)
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		require.Equal(t, lo.Range(15), values)
+		require.Equal(t, 5, lo.Count(fake.getCalls(), "FetchResults"))
+	})
+
+	t.Run("close stops prefetching", func(t *testing.T) {
+		fake := &fakeHS2{batches: 100}
+		db := startPrefetch(t, fake, 3, 2)
+		// stopping doesn't depend on which of the goroutine's channels are ready first, so each query is checked
+		for range 20 {
+			fetches := lo.Count(fake.getCalls(), "FetchResults")
+			rows, err := db.QueryContext(ctx, "SELECT x FROM t")
+			require.NoError(t, err)
+			require.True(t, rows.Next())
+			require.NoError(t, rows.Close())
+
+			calls := fake.getCalls()
+			// the batch that is read, the batch in the channel, and the batch that is sent or dropped while stopping
+			require.LessOrEqual(t, lo.Count(calls, "FetchResults")-fetches, 3)
+			// the operation is closed after the fetching ends
+			require.Equal(t, "CloseOperation", calls[len(calls)-1])
+		}
+		calls := fake.getCalls()
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, calls, fake.getCalls())
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		fake := &fakeHS2{batches: 100, fetchLatency: time.Millisecond}
+		db := startPrefetch(t, fake, 3, 4)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		rows, err := db.QueryContext(ctx, "SELECT x FROM t")
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+		cancel()
+		for rows.Next() {
+		}
+		require.ErrorIs(t, rows.Err(), context.Canceled)
+		require.Less(t, lo.Count(fake.getCalls(), "FetchResults"), 100)
+	})
+
+	t.Run("info messages and progress while prefetching", func(t *testing.T) {
+		fake := &fakeHS2{batches: 10, pendingFetches: 2, fetchLatency: time.Millisecond, infoMessages: []string{"warning"}}
+		db := startPrefetch(t, fake, 3, 4)
+		// progress is not synchronized, so the race detector reports progress functions called by other goroutines
+		var progress []Progress
+		ctx := WithProgressFunc(ctx, func(_ context.Context, p Progress) { progress = append(progress, p) })
+		rawQuery(t, ctx, db, "SELECT x FROM t", func(rows driver.Rows) {
+			dest := make([]driver.Value, 1)
+			var err error
+			for reports := 0; err == nil; reports = len(progress) {
+				ql, qlErr := RowsQueryLog(ctx, rows)
+				require.NoError(t, qlErr)
+				require.Contains(t, ql.InfoMessages, "warning")
+				require.GreaterOrEqual(t, len(progress), reports)
+				err = rows.Next(dest)
+			}
+			require.ErrorIs(t, err, io.EOF)
+			require.NoError(t, rows.Close())
+		})
+		require.NotEmpty(t, progress)
+	})
+
+	t.Run("query log while prefetching", func(t *testing.T) {
+		fake := &fakeHS2{batches: 10, fetchLatency: time.Millisecond, queryLog: "log"}
+		db := startPrefetch(t, fake, 3, 4)
+		rawQuery(t, ctx, db, "SELECT x FROM t", func(rows driver.Rows) {
+			dest := make([]driver.Value, 1)
+			require.NoError(t, rows.Next(dest))
+			ql, err := RowsQueryLog(ctx, rows)
+			require.NoError(t, err)
+			require.Equal(t, "log", ql.Log)
+			for err == nil {
+				err = rows.Next(dest)
+			}
+			require.ErrorIs(t, err, io.EOF)
+			require.NoError(t, rows.Close())
+		})
+	})
+}
+
+// BenchmarkPrefetch reads results that take as long to process as to fetch, so prefetching can halve the time
+func BenchmarkPrefetch(b *testing.B) {
+	const (
+		batches   = 20
+		batchSize = 100
+		latency   = time.Millisecond
+	)
+	for _, prefetch := range []int{0, 1, 4} {
+		b.Run(fmt.Sprintf("prefetch=%d", prefetch), func(b *testing.B) {
+			db := startPrefetch(b, &fakeHS2{batches: batches, fetchLatency: latency}, batchSize, prefetch)
+			ctx := context.Background()
+			b.ResetTimer()
+			for range b.N {
+				rows, err := db.QueryContext(ctx, "SELECT x FROM t")
+				require.NoError(b, err)
+				n := 0
+				for rows.Next() {
+					if n++; n%batchSize == 0 {
+						// the processing of a batch
+						time.Sleep(latency)
+					}
+				}
+				require.NoError(b, rows.Err())
+				require.NoError(b, rows.Close())
+			}
+			b.ReportMetric(float64(b.N*batches*batchSize)/b.Elapsed().Seconds(), "rows/s")
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package impala
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/sclgo/impala-go/internal/generated/runtimeprofile"
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// ProfileFormat selects the format of a runtime profile
+type ProfileFormat int
+
+const (
+	// ProfileFormatString is the human-readable text profile, as shown by impala-shell and the Impala web UI
+	ProfileFormatString ProfileFormat = iota
+	// ProfileFormatBase64 is the compact format of the Impala query log: a compressed Thrift profile encoded as base64
+	ProfileFormatBase64
+	// ProfileFormatTree is the profile parsed as a tree of ProfileNode
+	ProfileFormatTree
+)
+
+// RuntimeProfile is the runtime profile of a query, with detailed execution statistics
+type RuntimeProfile struct {
+	Format ProfileFormat
+	// Text is the profile in ProfileFormatString and ProfileFormatBase64
+	Text string
+	// Root is the root node of the profile in ProfileFormatTree
+	Root *ProfileNode
+}
+
+// ProfileNode is a node in a runtime profile tree e.g. a query, a fragment or a plan node
+type ProfileNode struct {
+	Name string
+	// InfoStrings are the properties of the node in display order, such as the query state or plan
+	InfoStrings []ProfileInfoString
+	Counters    []ProfileCounter
+	Children    []*ProfileNode
+}
+
+// ProfileInfoString is a property of a ProfileNode
+type ProfileInfoString struct {
+	Key   string
+	Value string
+}
+
+// ProfileCounter is a counter of a ProfileNode
+type ProfileCounter struct {
+	Name string
+	// Unit is the Impala name of the value unit e.g. UNIT, BYTES, TIME_NS
+	Unit  string
+	Value int64
+}
+
+// profileSource is implemented by the driver rows
+type profileSource interface {
+	RuntimeProfile(ctx context.Context, format runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error)
+}
+
+// QueryProfile returns the runtime profile of the query with the given ID in the hi:lo format that Impala shows.
+// The query can run in another connection, or even have finished already, if the Impala coordinator of conn
+// still keeps its profile and the connection user is allowed to see it.
+// *sql.Conn implements ConnRawAccess.
+func QueryProfile(ctx context.Context, conn ConnRawAccess, queryID string, format ProfileFormat) (*RuntimeProfile, error) {
+	var profile *RuntimeProfile
+	err := conn.Raw(func(driverConn any) error {
+		impalaConn, ok := driverConn.(*isql.Conn)
+		if !ok {
+			return errors.New("runtime profiles are available only on Impala drivers")
+		}
+		var err error
+		profile, err = getProfile(format, func(format runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error) {
+			return impalaConn.RuntimeProfile(ctx, queryID, format)
+		})
+		return err
+	})
+	return profile, err
+}
+
+// RowsProfile returns the runtime profile of the query that rows are the result of. It can be called
+// before and after the rows are closed, but not concurrently with other methods of rows.
+// rows are the driver rows of the query, like for RowsQueryLog, and must be used only while sql.Conn.Raw
+// runs the callback that received the driver connection.
+func RowsProfile(ctx context.Context, rows driver.Rows, format ProfileFormat) (*RuntimeProfile, error) {
+	source, ok := rows.(profileSource)
+	if !ok {
+		return nil, errors.New("runtime profiles are available only for rows from Impala drivers")
+	}
+	return getProfile(format, func(format runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error) {
+		return source.RuntimeProfile(ctx, format)
+	})
+}
+
+// ProfileFromError returns the runtime profile in ProfileFormatString attached to the error of a failed query
+// by Options.ProfileOnError.
+func ProfileFromError(err error) (string, bool) {
+	var profileErr *isql.ProfileError
+	if errors.As(err, &profileErr) {
+		return profileErr.Profile, true
+	}
+	return "", false
+}
+
+func getProfile(format ProfileFormat, get func(runtimeprofile.TRuntimeProfileFormat) (string, *runtimeprofile.TRuntimeProfileTree, error)) (*RuntimeProfile, error) {
+	var thriftFormat runtimeprofile.TRuntimeProfileFormat
+	switch format {
+	case ProfileFormatString:
+		thriftFormat = runtimeprofile.TRuntimeProfileFormat_STRING
+	case ProfileFormatBase64:
+		thriftFormat = runtimeprofile.TRuntimeProfileFormat_BASE64
+	case ProfileFormatTree:
+		thriftFormat = runtimeprofile.TRuntimeProfileFormat_THRIFT
+	default:
+		return nil, fmt.Errorf("invalid profile format: %d", format)
+	}
+	text, tree, err := get(thriftFormat)
+	if err != nil {
+		return nil, err
+	}
+	profile := &RuntimeProfile{Format: format, Text: text}
+	if format == ProfileFormatTree {
+		nodes := tree.GetNodes()
+		if len(nodes) == 0 {
+			return nil, errors.New("impala: the server returned an empty profile tree")
+		}
+		var rest []*runtimeprofile.TRuntimeProfileNode
+		profile.Root, rest = profileNode(nodes)
+		if len(rest) > 0 {
+			return nil, fmt.Errorf("impala: the server returned a profile tree with %d extra nodes", len(rest))
+		}
+	}
+	return profile, nil
+}
+
+// profileNode builds the subtree rooted at the first of the nodes, which are ordered by pre-order traversal,
+// and returns the nodes that are not part of the subtree
+func profileNode(nodes []*runtimeprofile.TRuntimeProfileNode) (*ProfileNode, []*runtimeprofile.TRuntimeProfileNode) {
+	tNode, rest := nodes[0], nodes[1:]
+	node := &ProfileNode{Name: tNode.GetName()}
+	for _, key := range tNode.GetInfoStringsDisplayOrder() {
+		node.InfoStrings = append(node.InfoStrings, ProfileInfoString{Key: key, Value: tNode.GetInfoStrings()[key]})
+	}
+	for _, c := range tNode.GetCounters() {
+		node.Counters = append(node.Counters, ProfileCounter{Name: c.GetName(), Unit: c.GetUnit().String(), Value: c.GetValue()})
+	}
+	for i := int32(0); i < tNode.GetNumChildren() && len(rest) > 0; i++ {
+		var child *ProfileNode
+		child, rest = profileNode(rest)
+		node.Children = append(node.Children, child)
+	}
+	return node, rest
+}
@@ -0,0 +1,90 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeProfile(t *testing.T) {
+	ctx := context.Background()
+	start := func(t *testing.T, fake *fakeHS2, profileOnError bool) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.ProfileOnError = profileOnError
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		db := start(t, &fakeHS2{}, false)
+		rawQuery(t, ctx, db, "SELECT 1", func(rows driver.Rows) {
+			profile, err := RowsProfile(ctx, rows, ProfileFormatString)
+			require.NoError(t, err)
+			require.Equal(t, ProfileFormatString, profile.Format)
+			require.Contains(t, profile.Text, "Query (id=")
+			require.Nil(t, profile.Root)
+
+			profile, err = RowsProfile(ctx, rows, ProfileFormatTree)
+			require.NoError(t, err)
+			require.Empty(t, profile.Text)
+			require.Contains(t, profile.Root.Name, "Query (id=")
+			require.Equal(t, []*ProfileNode{{
+				Name:        "Summary",
+				InfoStrings: []ProfileInfoString{{Key: "Query State", Value: "FINISHED"}},
+				Counters:    []ProfileCounter{{Name: "TotalTime", Unit: "TIME_NS", Value: 5}},
+			}}, profile.Root.Children)
+
+			require.NoError(t, rows.Close())
+			profile, err = RowsProfile(ctx, rows, ProfileFormatBase64)
+			require.NoError(t, err)
+			require.NotEmpty(t, profile.Text)
+		})
+	})
+
+	t.Run("query id", func(t *testing.T) {
+		db := start(t, &fakeHS2{}, false)
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		profile, err := QueryProfile(ctx, conn, "0123456789abcdef:fedcba9876543210", ProfileFormatString)
+		require.NoError(t, err)
+		require.Contains(t, profile.Text, "Query (id=0123456789abcdef:fedcba9876543210)")
+
+		_, err = QueryProfile(ctx, conn, "0123456789abcdef", ProfileFormatString)
+		require.ErrorContains(t, err, "invalid query ID")
+		_, err = QueryProfile(ctx, conn, "0123456789abcdef:fedcba9876543210", ProfileFormat(10))
+		require.ErrorContains(t, err, "invalid profile format")
+	})
+
+	t.Run("profile on error", func(t *testing.T) {
+		db := start(t, &fakeHS2{queryFails: true}, true)
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.ErrorContains(t, err, "Memory limit exceeded")
+		profile, ok := ProfileFromError(err)
+		require.True(t, ok)
+		require.Contains(t, profile, "Query (id=")
+	})
+
+	t.Run("no profile on error by default", func(t *testing.T) {
+		fake := &fakeHS2{queryFails: true}
+		db := start(t, fake, false)
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.ErrorContains(t, err, "Memory limit exceeded")
+		_, ok := ProfileFromError(err)
+		require.False(t, ok)
+		require.NotContains(t, fake.getCalls(), "GetRuntimeProfile")
+	})
+
+	t.Run("not Impala rows", func(t *testing.T) {
+		_, err := RowsProfile(ctx, nil, ProfileFormatString)
+		require.ErrorContains(t, err, "only for rows from Impala drivers")
+	})
+}
@@ -0,0 +1,117 @@
+package impala
+
+import (
+	"context"
+	"time"
+
+	"github.com/sclgo/impala-go/internal/generated/execstats"
+	"github.com/sclgo/impala-go/internal/hive"
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// ProgressFunc receives the progress of a running query. It is called synchronously by the goroutine
+// waiting for the query so it should return quickly. The context is the one of the waiting operation.
+type ProgressFunc func(ctx context.Context, progress Progress)
+
+// Progress is a snapshot of the execution of a query, taken from the Impala exec summary
+type Progress struct {
+	// QueryID is the Impala query ID in the hi:lo format that Impala shows
+	QueryID string
+	// State is the Impala execution state e.g. PLANNING, QUEUED, RUNNING, FINISHED
+	State string
+	// Queued tells if the query waits in an admission control queue. QueuedReason tells why.
+	Queued       bool
+	QueuedReason string
+	// TotalScanRanges and CompletedScanRanges measure the progress of the scans of the query.
+	// Both are 0 until the query starts running.
+	TotalScanRanges     int64
+	CompletedScanRanges int64
+	// Nodes are the plan nodes and data sinks of the query, in pre-order of the plan tree of each fragment
+	Nodes []ProgressNode
+}
+
+// Fraction returns the share of completed scan ranges between 0 and 1, or 0 if the query doesn't scan yet
+func (p Progress) Fraction() float64 {
+	if p.TotalScanRanges <= 0 {
+		return 0
+	}
+	return float64(p.CompletedScanRanges) / float64(p.TotalScanRanges)
+}
+
+// ProgressNode has the statistics of a plan node or data sink, aggregated over the fragment instances running it
+type ProgressNode struct {
+	// NodeID is the plan node ID or -1 for the data sink at the root of a fragment
+	NodeID int32
+	// Fragment is the index of the plan fragment of the node
+	Fragment int32
+	// Label and Detail are shown in the exec summary of impala-shell e.g. "00:SCAN HDFS" and "default.t"
+	Label  string
+	Detail string
+	Hosts  int32
+	// Instances is the number of fragment instances that run the node and report statistics
+	Instances int
+	// Rows is the total number of rows returned by the node
+	Rows int64
+	// PeakMemory is the largest peak memory usage of a single instance, in bytes
+	PeakMemory int64
+	// MaxTime is the longest wall clock time of a single instance. CPUTime is the total over all instances.
+	MaxTime time.Duration
+	CPUTime time.Duration
+	// EstimatedRows and EstimatedPeakMemory are the planner estimates
+	EstimatedRows       int64
+	EstimatedPeakMemory int64
+}
+
+// WithProgressFunc returns a context that makes query operations report progress to fn, overriding
+// Options.ProgressFunc. A nil fn disables progress reporting. Progress is reported while the driver polls
+// a query that isn't finished: while ExecContext and AsyncQuery.Wait wait for it, and while rows wait for
+// the next batch of results. Getting each report costs an extra roundtrip to the server.
+func WithProgressFunc(ctx context.Context, fn ProgressFunc) context.Context {
+	return isql.WithProgressFunc(ctx, hiveProgressFunc(fn))
+}
+
+// hiveProgressFunc adapts fn to the internal exec summary callback
+func hiveProgressFunc(fn ProgressFunc) hive.ProgressFunc {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context, queryID string, summary *execstats.TExecSummary) {
+		fn(ctx, newProgress(queryID, summary))
+	}
+}
+
+func newProgress(queryID string, summary *execstats.TExecSummary) Progress {
+	progress := Progress{
+		QueryID:      queryID,
+		State:        summary.GetState().String(),
+		Queued:       summary.GetIsQueued(),
+		QueuedReason: summary.GetQueuedReason(),
+	}
+	// the getters of generated structs don't accept nil receivers
+	if summary.IsSetProgress() {
+		progress.TotalScanRanges = summary.Progress.GetTotalScanRanges()
+		progress.CompletedScanRanges = summary.Progress.GetNumCompletedScanRanges()
+	}
+	for _, tNode := range summary.GetNodes() {
+		node := ProgressNode{
+			NodeID:    tNode.GetNodeID(),
+			Fragment:  tNode.GetFragmentIdx(),
+			Label:     tNode.GetLabel(),
+			Detail:    tNode.GetLabelDetail(),
+			Hosts:     tNode.GetNumHosts(),
+			Instances: len(tNode.GetExecStats()),
+		}
+		if tNode.IsSetEstimatedStats() {
+			node.EstimatedRows = tNode.EstimatedStats.GetCardinality()
+			node.EstimatedPeakMemory = tNode.EstimatedStats.GetMemoryUsed()
+		}
+		for _, stats := range tNode.GetExecStats() {
+			node.Rows += stats.GetCardinality()
+			node.PeakMemory = max(node.PeakMemory, stats.GetMemoryUsed())
+			node.MaxTime = max(node.MaxTime, time.Duration(stats.GetLatencyNs()))
+			node.CPUTime += time.Duration(stats.GetCPUTimeNs())
+		}
+		progress.Nodes = append(progress.Nodes, node)
+	}
+	return progress
+}
@@ -0,0 +1,109 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgress(t *testing.T) {
+	ctx := context.Background()
+	start := func(t *testing.T, fake *fakeHS2, fn ProgressFunc) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.ProgressFunc = fn
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+	type recorder struct {
+		mu       sync.Mutex
+		progress []Progress
+	}
+	record := func(r *recorder) ProgressFunc {
+		return func(_ context.Context, p Progress) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.progress = append(r.progress, p)
+		}
+	}
+
+	t.Run("exec", func(t *testing.T) {
+		var r recorder
+		db := start(t, &fakeHS2{runningPolls: 2}, record(&r))
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+
+		require.Len(t, r.progress, 3)
+		queued, running, finished := r.progress[0], r.progress[1], r.progress[2]
+		require.Equal(t, "QUEUED", queued.State)
+		require.True(t, queued.Queued)
+		require.Equal(t, "queue is full", queued.QueuedReason)
+		require.Zero(t, queued.Fraction())
+		require.Regexp(t, "^[0-9a-f]{16}:[0-9a-f]{16}$", queued.QueryID)
+
+		require.Equal(t, "RUNNING", running.State)
+		require.False(t, running.Queued)
+		require.InDelta(t, 0.5, running.Fraction(), 0.001)
+
+		require.Equal(t, "FINISHED", finished.State)
+		require.InDelta(t, 1, finished.Fraction(), 0.001)
+		require.Equal(t, queued.QueryID, finished.QueryID)
+		require.Equal(t, []ProgressNode{{
+			NodeID:              0,
+			Fragment:            0,
+			Label:               "00:SCAN HDFS",
+			Detail:              "default.t",
+			Hosts:               2,
+			Instances:           2,
+			Rows:                90,
+			PeakMemory:          512,
+			MaxTime:             7 * time.Nanosecond,
+			CPUTime:             7 * time.Nanosecond,
+			EstimatedRows:       100,
+			EstimatedPeakMemory: 1024,
+		}}, finished.Nodes)
+	})
+
+	t.Run("context overrides options", func(t *testing.T) {
+		var fromOpts, fromCtx recorder
+		db := start(t, &fakeHS2{runningPolls: 1}, record(&fromOpts))
+		_, err := db.ExecContext(WithProgressFunc(ctx, record(&fromCtx)), "INSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+		require.Empty(t, fromOpts.progress)
+		require.Len(t, fromCtx.progress, 2)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		var r recorder
+		fake := &fakeHS2{runningPolls: 1}
+		db := start(t, fake, record(&r))
+		_, err := db.ExecContext(WithProgressFunc(ctx, nil), "INSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+		require.Empty(t, r.progress)
+		require.NotContains(t, fake.getCalls(), "GetExecSummary")
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		db := start(t, &fakeHS2{fetchPending: true}, nil)
+		queryCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var r recorder
+		rows, err := db.QueryContext(WithProgressFunc(queryCtx, func(ctx context.Context, p Progress) {
+			record(&r)(ctx, p)
+			cancel() // stop waiting for results that never come
+		}), "SELECT * FROM t")
+		require.NoError(t, err)
+		defer rows.Close()
+		require.False(t, rows.Next())
+		require.ErrorIs(t, rows.Err(), context.Canceled)
+		require.Len(t, r.progress, 1)
+		require.NotEmpty(t, r.progress[0].QueryID)
+	})
+}
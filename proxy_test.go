@@ -0,0 +1,235 @@
+package impala
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/murfffi/gorich/fi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProxy(t *testing.T) {
+	for _, proxyType := range []string{"socks5", "socks5h", "http"} {
+		t.Run(proxyType+" binary transport with TLS", func(t *testing.T) {
+			port, peers := startTLSServer(t, tls.NoClientCert)
+			proxy := startTestProxy(t, proxyType)
+
+			opts := DefaultOptions
+			opts.Host = "localhost"
+			opts.Port = strconv.Itoa(port)
+			opts.UseTLS = true
+			opts.CACertPath = testCertPath
+			opts.Proxy = proxyType + "://user:pass@" + proxy.addr
+
+			conn, err := connect(context.Background(), &opts, nil)
+			require.NoError(t, err)
+			<-peers // the TLS handshake completed through the tunnel
+			require.NoError(t, conn.Close())
+			target := "localhost"
+			if proxyType == "socks5" {
+				// resolved locally
+				target = "127.0.0.1"
+			}
+			require.Equal(t, []string{net.JoinHostPort(target, opts.Port)}, proxy.getTargets())
+			require.Equal(t, []string{"user:pass"}, proxy.getCreds())
+		})
+
+		t.Run(proxyType+" http transport", func(t *testing.T) {
+			fake := &fakeHS2{}
+			srv := httptest.NewServer(fake.httpHandler())
+			defer srv.Close()
+			proxy := startTestProxy(t, proxyType)
+
+			opts := httpTestOptions(t, srv.URL)
+			opts.Proxy = proxyType + "://" + proxy.addr
+			pingAndClose(t, opts)
+			require.NotEmpty(t, proxy.getTargets())
+		})
+	}
+
+	t.Run("custom dialer", func(t *testing.T) {
+		port := createUnresponsiveSocket(t)
+		var dialed []string
+		opts := DefaultOptions
+		opts.Host = "127.0.0.1"
+		opts.Port = strconv.Itoa(port)
+		opts.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		}
+		conn, err := connect(context.Background(), &opts, nil)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		require.Equal(t, []string{net.JoinHostPort(opts.Host, opts.Port)}, dialed)
+	})
+
+	t.Run("proxy unreachable", func(t *testing.T) {
+		opts := DefaultOptions
+		opts.Host = "localhost"
+		opts.Proxy = "socks5://127.0.0.1:" + strconv.Itoa(createClosedPort(t))
+		_, err := connect(context.Background(), &opts, nil)
+		require.ErrorIs(t, err, ErrOpenFailed)
+		require.ErrorContains(t, err, "proxy")
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		_, err := (&Driver{}).Open("impala://localhost?proxy=ftp://host")
+		require.ErrorIs(t, err, ErrBadDSN)
+		require.ErrorContains(t, err, "invalid proxy")
+	})
+}
+
+// testProxy is a minimal SOCKS5 or HTTP CONNECT proxy
+type testProxy struct {
+	addr string
+
+	mu      sync.Mutex
+	targets []string
+	creds   []string
+}
+
+func startTestProxy(t *testing.T, proxyType string) *testProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	fi.CleanupF(t, listener.Close)
+	p := &testProxy{addr: listener.Addr().String()}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if proxyType != "http" {
+				go p.serveSOCKS5(conn)
+			} else {
+				go p.serveConnect(conn)
+			}
+		}
+	}()
+	return p
+}
+
+func (p *testProxy) record(target string, creds string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.targets = append(p.targets, target)
+	if creds != "" {
+		p.creds = append(p.creds, creds)
+	}
+}
+
+func (p *testProxy) getTargets() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.targets...)
+}
+
+func (p *testProxy) getCreds() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.creds...)
+}
+
+func (p *testProxy) serveSOCKS5(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	greeting := make([]byte, 2)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		return
+	}
+	methods := make([]byte, greeting[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return
+	}
+	var creds string
+	if methods[0] == socks5AuthPassword {
+		_, _ = conn.Write([]byte{socks5Version, socks5AuthPassword})
+		creds = readSOCKS5Creds(conn)
+		_, _ = conn.Write([]byte{1, 0})
+	} else {
+		_, _ = conn.Write([]byte{socks5Version, socks5AuthNone})
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	var host string
+	switch header[3] {
+	case socks5AddrDomain:
+		l := make([]byte, 1)
+		_, _ = io.ReadFull(conn, l)
+		name := make([]byte, l[0])
+		_, _ = io.ReadFull(conn, name)
+		host = string(name)
+	case socks5AddrIPv4:
+		ip := make([]byte, net.IPv4len)
+		_, _ = io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	default:
+		return
+	}
+	portBytes := make([]byte, 2)
+	_, _ = io.ReadFull(conn, portBytes)
+	target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(portBytes))))
+	p.record(target, creds)
+
+	upstream, err := net.Dial("tcp", target)
+	if err != nil {
+		_, _ = conn.Write([]byte{socks5Version, 5, 0, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	_, _ = conn.Write([]byte{socks5Version, 0, 0, socks5AddrIPv4, 0, 0, 0, 0, 0, 0})
+	pipe(conn, upstream)
+}
+
+func readSOCKS5Creds(conn net.Conn) string {
+	header := make([]byte, 2)
+	_, _ = io.ReadFull(conn, header)
+	user := make([]byte, header[1])
+	_, _ = io.ReadFull(conn, user)
+	l := make([]byte, 1)
+	_, _ = io.ReadFull(conn, l)
+	pass := make([]byte, l[0])
+	_, _ = io.ReadFull(conn, pass)
+	return string(user) + ":" + string(pass)
+}
+
+func (p *testProxy) serveConnect(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil || req.Method != http.MethodConnect {
+		return
+	}
+	var creds string
+	if req.Header.Get("Proxy-Authorization") != "" {
+		req.Header.Set("Authorization", req.Header.Get("Proxy-Authorization"))
+		user, pass, _ := req.BasicAuth()
+		creds = user + ":" + pass
+	}
+	p.record(req.Host, creds)
+	upstream, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+	pipe(conn, upstream)
+}
+
+func pipe(a net.Conn, b net.Conn) {
+	defer func() { _ = b.Close() }()
+	go func() {
+		_, _ = io.Copy(b, a)
+		_ = b.Close()
+	}()
+	_, _ = io.Copy(a, b)
+}
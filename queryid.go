@@ -0,0 +1,44 @@
+package impala
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// QueryIDFunc receives the Impala query ID of a statement as soon as the server accepts the statement,
+// before the statement finishes. It is called synchronously so it should return quickly.
+type QueryIDFunc func(ctx context.Context, queryID string)
+
+// WithQueryIDFunc returns a context that makes statements pass their Impala query ID to fn.
+// Query IDs are in the hi:lo format that Impala shows e.g. in its web UI and in runtime profiles.
+func WithQueryIDFunc(ctx context.Context, fn QueryIDFunc) context.Context {
+	return isql.WithQueryIDFunc(ctx, isql.QueryIDFunc(fn))
+}
+
+// queryIDSource is implemented by the driver rows
+type queryIDSource interface {
+	QueryID() string
+}
+
+// RowsQueryID returns the Impala query ID of the query that rows are the result of. rows are driver rows,
+// like for RowsQueryLog. It returns false if rows are not returned by an Impala driver connection.
+func RowsQueryID(rows driver.Rows) (string, bool) {
+	source, ok := rows.(queryIDSource)
+	if !ok {
+		return "", false
+	}
+	return source.QueryID(), true
+}
+
+// QueryIDFromError returns the Impala query ID attached to the error of a statement that the server accepted.
+// Errors of statements that the server rejected, e.g. because of a syntax error, don't have a query ID.
+func QueryIDFromError(err error) (string, bool) {
+	var idErr *isql.QueryIDError
+	if errors.As(err, &idErr) {
+		return idErr.QueryID, true
+	}
+	return "", false
+}
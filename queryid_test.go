@@ -0,0 +1,73 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryID(t *testing.T) {
+	ctx := context.Background()
+	const idPattern = "^[0-9a-f]{16}:[0-9a-f]{16}$"
+	start := func(t *testing.T, fake *fakeHS2) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		db := sql.OpenDB(NewConnector(httpTestOptions(t, srv.URL)))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+	record := func(ids *[]string) context.Context {
+		return WithQueryIDFunc(ctx, func(_ context.Context, queryID string) {
+			*ids = append(*ids, queryID)
+		})
+	}
+
+	t.Run("rows", func(t *testing.T) {
+		db := start(t, &fakeHS2{})
+		var ids []string
+		rawQuery(t, record(&ids), db, "SELECT 1", func(rows driver.Rows) {
+			require.Len(t, ids, 1)
+			require.Regexp(t, idPattern, ids[0])
+
+			id, ok := RowsQueryID(rows)
+			require.True(t, ok)
+			require.Equal(t, ids[0], id)
+			require.NoError(t, rows.Close())
+		})
+
+		_, ok := RowsQueryID(nil)
+		require.False(t, ok)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db := start(t, &fakeHS2{queryFails: true})
+		var ids []string
+		_, err := db.ExecContext(record(&ids), "INSERT INTO t SELECT * FROM s")
+		require.ErrorContains(t, err, "Memory limit exceeded")
+		require.Len(t, ids, 1)
+		require.ErrorContains(t, err, "(query id: "+ids[0]+")")
+		id, ok := QueryIDFromError(err)
+		require.True(t, ok)
+		require.Equal(t, ids[0], id)
+
+		_, ok = QueryIDFromError(context.Canceled)
+		require.False(t, ok)
+	})
+
+	t.Run("async", func(t *testing.T) {
+		db := start(t, &fakeHS2{})
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		var ids []string
+		h, err := AsyncQuery{}.Submit(record(&ids), conn, "SELECT 1")
+		require.NoError(t, err)
+		require.Equal(t, []string{h.QueryID()}, ids)
+		_, err = AsyncQuery{}.Close(ctx, conn, h)
+		require.NoError(t, err)
+	})
+}
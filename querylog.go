@@ -0,0 +1,74 @@
+package impala
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// QueryLog has the messages that the server reported for a query, besides its results
+type QueryLog struct {
+	// QueryID is the Impala query ID in the hi:lo format that Impala shows
+	QueryID string
+	// Log is the operation log of the query. Impala logs the warnings and errors of the query there,
+	// such as missing table statistics, skipped corrupt files and admission control queueing.
+	Log string
+	// InfoMessages are the info messages of the server responses for the query
+	InfoMessages []string
+}
+
+// Warnings returns the non-empty lines of Log followed by InfoMessages
+func (l *QueryLog) Warnings() []string {
+	var warnings []string
+	for _, line := range strings.Split(l.Log, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			warnings = append(warnings, line)
+		}
+	}
+	return append(warnings, l.InfoMessages...)
+}
+
+// QueryLogFunc receives the log of a query. It is called synchronously, before the query is closed on the server.
+type QueryLogFunc func(ctx context.Context, log *QueryLog)
+
+// queryLogSource is implemented by the driver rows
+type queryLogSource interface {
+	QueryLog(ctx context.Context) (string, []string, error)
+	QueryID() string
+}
+
+// WithQueryLogFunc returns a context that makes queries report their log to fn, overriding Options.QueryLogFunc.
+// A nil fn disables log reporting. The log is reported when ExecContext finishes, including when the statement fails,
+// and when rows from QueryContext are closed, unless the context is done. If the log can't be retrieved, fn still
+// receives the info messages. Getting each log costs an extra roundtrip to the server.
+func WithQueryLogFunc(ctx context.Context, fn QueryLogFunc) context.Context {
+	return isql.WithQueryLogFunc(ctx, isqlQueryLogFunc(fn))
+}
+
+// RowsQueryLog returns the log of the query that rows are the result of. It must be called before rows are closed
+// and not concurrently with other methods of rows. rows must be the driver rows returned by QueryContext of an Impala
+// driver connection, which sql.Conn.Raw exposes. database/sql doesn't give access to the driver rows of sql.Rows.
+func RowsQueryLog(ctx context.Context, rows driver.Rows) (*QueryLog, error) {
+	source, ok := rows.(queryLogSource)
+	if !ok {
+		return nil, errors.New("query logs are available only for rows from Impala drivers")
+	}
+	log, infoMessages, err := source.QueryLog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryLog{QueryID: source.QueryID(), Log: log, InfoMessages: infoMessages}, nil
+}
+
+// isqlQueryLogFunc adapts fn to the internal log callback
+func isqlQueryLogFunc(fn QueryLogFunc) isql.QueryLogFunc {
+	if fn == nil {
+		return nil
+	}
+	return func(ctx context.Context, queryID string, log string, infoMessages []string) {
+		fn(ctx, &QueryLog{QueryID: queryID, Log: log, InfoMessages: infoMessages})
+	}
+}
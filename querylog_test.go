@@ -0,0 +1,99 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryLog(t *testing.T) {
+	ctx := context.Background()
+	const queryLog = "Missing statistics for table: default.t\n\nSkipped corrupt file: /data/t/1.parq\n"
+	start := func(t *testing.T, fake *fakeHS2, fn QueryLogFunc) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.QueryLogFunc = fn
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+	record := func(logs *[]*QueryLog) QueryLogFunc {
+		return func(_ context.Context, log *QueryLog) {
+			*logs = append(*logs, log)
+		}
+	}
+
+	t.Run("exec", func(t *testing.T) {
+		var logs []*QueryLog
+		db := start(t, &fakeHS2{queryLog: queryLog, infoMessages: []string{"3 rows skipped"}}, record(&logs))
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.Regexp(t, "^[0-9a-f]{16}:[0-9a-f]{16}$", logs[0].QueryID)
+		require.Equal(t, queryLog, logs[0].Log)
+		require.Equal(t, []string{
+			"Missing statistics for table: default.t",
+			"Skipped corrupt file: /data/t/1.parq",
+			"3 rows skipped",
+		}, logs[0].Warnings())
+	})
+
+	t.Run("failed exec", func(t *testing.T) {
+		var logs []*QueryLog
+		db := start(t, &fakeHS2{queryLog: queryLog, queryFails: true}, record(&logs))
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.ErrorContains(t, err, "Memory limit exceeded")
+		require.Len(t, logs, 1)
+		require.Equal(t, queryLog, logs[0].Log)
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		var fromOpts, fromCtx []*QueryLog
+		db := start(t, &fakeHS2{queryLog: queryLog}, record(&fromOpts))
+		rawQuery(t, WithQueryLogFunc(ctx, record(&fromCtx)), db, "SELECT * FROM t", func(rows driver.Rows) {
+			log, err := RowsQueryLog(ctx, rows)
+			require.NoError(t, err)
+			require.Equal(t, queryLog, log.Log)
+			require.NotEmpty(t, log.QueryID)
+			require.Empty(t, fromCtx)
+
+			require.NoError(t, rows.Close())
+			require.Equal(t, []*QueryLog{log}, fromCtx)
+			require.Empty(t, fromOpts)
+		})
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		fake := &fakeHS2{queryLog: queryLog}
+		db := start(t, fake, nil)
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT * FROM s")
+		require.NoError(t, err)
+		require.NotContains(t, fake.getCalls(), "GetLog")
+	})
+
+	t.Run("not Impala rows", func(t *testing.T) {
+		_, err := RowsQueryLog(ctx, nil)
+		require.ErrorContains(t, err, "only for rows from Impala drivers")
+	})
+}
+
+// rawQuery runs query with an Impala driver connection of db and calls f with the driver rows, which f must close
+func rawQuery(t *testing.T, ctx context.Context, db *sql.DB, query string, f func(rows driver.Rows)) {
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	err = conn.Raw(func(driverConn any) error {
+		rows, err := driverConn.(driver.QueryerContext).QueryContext(ctx, query, nil)
+		if err != nil {
+			return err
+		}
+		f(rows)
+		return nil
+	})
+	require.NoError(t, err)
+}
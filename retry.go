@@ -0,0 +1,54 @@
+package impala
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/sclgo/impala-go/internal/hive"
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// RetryPolicy makes the driver run idempotent statements again after transient failures, such as a coordinator
+// restart or the failure of an executor. After transport failures, the connection reconnects before the next
+// attempt, trying the hosts of Options.Hosts like a new connection. Impala errors are retried if they report
+// unreachable or failed executors or that the query was retried by Impala.
+//
+// Failures of Query methods are retried only until the first rows are available, because the rows of
+// another run can't be merged with the rows that were already read. Multi-statement queries and Exec methods
+// in ExecModeAsync are not retried. Each retry is logged to Options.LogOut with the query IDs of the failed
+// and the new run.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of runs of a statement, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with each retry, up to MaxBackoff if that is larger.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Idempotent tells if a statement can run again safely. If nil, DefaultIdempotent is used.
+	Idempotent func(stmt string) bool
+}
+
+// DefaultIdempotent returns true for read-only statements: SELECT, VALUES, WITH ... SELECT, SHOW, DESCRIBE and EXPLAIN,
+// ignoring leading comments and parentheses.
+// Note that SELECT statements with side effects, e.g. calling UDFs that write to external systems, are not idempotent.
+func DefaultIdempotent(stmt string) bool {
+	return isql.DefaultIdempotent(stmt)
+}
+
+// isqlRetryPolicy returns the internal retry policy for opts, which reconnects as a new connection with opts
+func isqlRetryPolicy(opts *Options, logger *log.Logger) *isql.RetryPolicy {
+	if opts.Retry == nil {
+		return nil
+	}
+	return &isql.RetryPolicy{
+		MaxAttempts: opts.Retry.MaxAttempts,
+		Backoff:     opts.Retry.Backoff,
+		MaxBackoff:  opts.Retry.MaxBackoff,
+		Idempotent:  opts.Retry.Idempotent,
+		Reconnect: func(ctx context.Context) (thrift.TTransport, *hive.Client, error) {
+			return dial(ctx, opts, logger)
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package impala
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	start := func(t *testing.T, fake *fakeHS2, retry *RetryPolicy) (*sql.DB, *bytes.Buffer) {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.Retry = retry
+		var logOut bytes.Buffer
+		opts.LogOut = &logOut
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db, &logOut
+	}
+	policy := &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	t.Run("query after executor failure", func(t *testing.T) {
+		fake := &fakeHS2{retryableFailures: 2}
+		db, logOut := start(t, fake, policy)
+		var x int
+		require.NoError(t, db.QueryRowContext(ctx, "/* report */ SELECT 1").Scan(&x))
+		require.Equal(t, 1, x)
+		require.Equal(t, 3, lo.Count(fake.getCalls(), "ExecuteStatement"))
+
+		retries := lo.Filter(strings.Split(logOut.String(), "\n"), func(line string, _ int) bool {
+			return strings.Contains(line, " retried as query ")
+		})
+		require.Len(t, retries, 1)
+		require.Regexp(t, `query [0-9a-f]{16}:[0-9a-f]{16} retried as query [0-9a-f]{16}:[0-9a-f]{16}$`, retries[0])
+	})
+
+	t.Run("exec after executor failure", func(t *testing.T) {
+		fake := &fakeHS2{retryableFailures: 1}
+		db, _ := start(t, fake, policy)
+		_, err := db.ExecContext(ctx, "SHOW TABLES")
+		require.NoError(t, err)
+		require.Equal(t, 2, lo.Count(fake.getCalls(), "ExecuteStatement"))
+	})
+
+	t.Run("reconnect after transport failure", func(t *testing.T) {
+		fake := &fakeHS2{droppedExecutes: 1}
+		db, logOut := start(t, fake, policy)
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.PingContext(ctx))
+
+		var x int
+		require.NoError(t, conn.QueryRowContext(ctx, "SELECT 1").Scan(&x))
+		calls := fake.getCalls()
+		require.Equal(t, 1, lo.Count(calls, "ExecuteStatement dropped"))
+		require.Equal(t, 1, lo.Count(calls, "ExecuteStatement"))
+		// the session of the failed transport is dropped and a new one is opened
+		require.Equal(t, 2, lo.Count(calls, "OpenSession"))
+		require.Contains(t, logOut.String(), "query  retried as query ")
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		fake := &fakeHS2{retryableFailures: 5}
+		db, _ := start(t, fake, policy)
+		_, err := db.ExecContext(ctx, "SELECT 1")
+		require.ErrorContains(t, err, "unreachable impalad")
+		require.Equal(t, 3, lo.Count(fake.getCalls(), "ExecuteStatement"))
+	})
+
+	t.Run("not idempotent", func(t *testing.T) {
+		fake := &fakeHS2{retryableFailures: 1}
+		db, _ := start(t, fake, policy)
+		_, err := db.ExecContext(ctx, "INSERT INTO t SELECT 1")
+		require.ErrorContains(t, err, "unreachable impalad")
+		require.Equal(t, 1, lo.Count(fake.getCalls(), "ExecuteStatement"))
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		fake := &fakeHS2{retryableFailures: 1}
+		db, _ := start(t, fake, &RetryPolicy{MaxAttempts: 2, Idempotent: func(stmt string) bool {
+			return strings.HasPrefix(stmt, "INSERT OVERWRITE")
+		}})
+		_, err := db.ExecContext(ctx, "INSERT OVERWRITE t SELECT 1")
+		require.NoError(t, err)
+		require.Equal(t, 2, lo.Count(fake.getCalls(), "ExecuteStatement"))
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		fake := &fakeHS2{queryFails: true}
+		db, _ := start(t, fake, policy)
+		_, err := db.ExecContext(ctx, "SELECT 1")
+		require.ErrorContains(t, err, "Memory limit exceeded")
+		require.Equal(t, 1, lo.Count(fake.getCalls(), "ExecuteStatement"))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		fake := &fakeHS2{retryableFailures: 1}
+		db, _ := start(t, fake, nil)
+		_, err := db.ExecContext(ctx, "SELECT 1")
+		require.ErrorContains(t, err, "unreachable impalad")
+		require.Equal(t, 1, lo.Count(fake.getCalls(), "ExecuteStatement"))
+	})
+}
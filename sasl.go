@@ -0,0 +1,45 @@
+package impala
+
+import (
+	"github.com/sclgo/impala-go/internal/sasl"
+)
+
+// SASLMechanism is a client-side SASL mechanism, used to authenticate connections over the binary (TCP) transport.
+// A new instance is created for each connection, and it is not used concurrently.
+// Start and Step drive the negotiation. After successful negotiation, the driver calls QOP and,
+// if the negotiated quality of protection is not SASLQOPAuth, passes each message through Wrap and Unwrap.
+type SASLMechanism = sasl.Mechanism
+
+// SASLOptions contains the parameters, derived from Options, that a SASLMechanism may need
+type SASLOptions = sasl.Options
+
+// SASLMechanismFactory creates a SASLMechanism instance for a single connection
+type SASLMechanismFactory = sasl.MechanismFactory
+
+// SASLQOP is SASL quality of protection
+type SASLQOP = sasl.QOP
+
+// SASL quality of protection values
+const (
+	SASLQOPAuth     = sasl.QOPAuth
+	SASLQOPAuthInt  = sasl.QOPAuthInt
+	SASLQOPAuthConf = sasl.QOPAuthConf
+)
+
+// SASL mechanism names used by the driver
+const (
+	// SASLMechPlain is used when Options.UseLDAP is set. The driver includes an implementation.
+	SASLMechPlain = sasl.MechPlain
+	// SASLMechGSSAPI is used when Options.UseKerberos is set. The driver doesn't include an implementation
+	// so one must be registered with RegisterSASLMechanism.
+	SASLMechGSSAPI = sasl.MechGSSAPI
+)
+
+// RegisterSASLMechanism makes a SASL mechanism available to the driver by name.
+// Registering a name that is already registered, including SASLMechPlain, replaces the existing mechanism.
+//
+// The driver selects SASLMechGSSAPI when Kerberos authentication is enabled. Register a GSSAPI implementation,
+// typically a wrapper of a Kerberos library, under that name before opening connections.
+func RegisterSASLMechanism(name string, factory SASLMechanismFactory) {
+	sasl.Register(name, factory)
+}
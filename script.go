@@ -0,0 +1,17 @@
+package impala
+
+import (
+	"errors"
+
+	"github.com/sclgo/impala-go/internal/isql"
+)
+
+// StatementFromError returns the 0-based index and the text of the statement of a multi-statement query
+// that failed with err. See Options.MultiStatements.
+func StatementFromError(err error) (index int, statement string, ok bool) {
+	var stmtErr *isql.StatementError
+	if errors.As(err, &stmtErr) {
+		return stmtErr.Index, stmtErr.Statement, true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,108 @@
+package impala
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclgo/impala-go/internal/generated/impalaservice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiStatements(t *testing.T) {
+	ctx := context.Background()
+	start := func(t *testing.T, fake *fakeHS2, multiStatements bool) *sql.DB {
+		srv := httptest.NewServer(fake.httpHandler())
+		t.Cleanup(srv.Close)
+		opts := httpTestOptions(t, srv.URL)
+		opts.MultiStatements = multiStatements
+		db := sql.OpenDB(NewConnector(opts))
+		t.Cleanup(func() { _ = db.Close() })
+		return db
+	}
+
+	t.Run("exec", func(t *testing.T) {
+		fake := &fakeHS2{dmlResult: &impalaservice.TDmlResult_{RowsModified: map[string]int64{"": 2}}}
+		db := start(t, fake, true)
+		var dml *DMLResult
+		execCtx := WithDMLResultFunc(ctx, func(_ context.Context, res *DMLResult) { dml = res })
+		res, err := db.ExecContext(execCtx, "SET MT_DOP=2; INSERT INTO t VALUES (?); INSERT INTO t VALUES ('a;b');", "x;y")
+		require.NoError(t, err)
+		require.Equal(t, []string{"SET MT_DOP=2", "INSERT INTO t VALUES ('x;y')", "INSERT INTO t VALUES ('a;b')"},
+			fake.getStatements())
+		rowsAffected, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(6), rowsAffected)
+		require.NotNil(t, dml)
+		require.Equal(t, map[string]int64{"": 6}, dml.RowsModified)
+	})
+
+	t.Run("result sets", func(t *testing.T) {
+		fake := &fakeHS2{}
+		db := start(t, fake, true)
+		rows, err := db.QueryContext(ctx, "SET MT_DOP=2; SELECT 1; INSERT INTO t SELECT 1; SELECT 2; SET MT_DOP=0")
+		require.NoError(t, err)
+		defer rows.Close()
+		require.Equal(t, []string{"SET MT_DOP=2", "SELECT 1"}, fake.getStatements())
+
+		var resultSets [][]int
+		for {
+			var values []int
+			for rows.Next() {
+				var x int
+				require.NoError(t, rows.Scan(&x))
+				values = append(values, x)
+			}
+			resultSets = append(resultSets, values)
+			if !rows.NextResultSet() {
+				break
+			}
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, [][]int{{1, 2}, {1, 2}}, resultSets)
+		require.Equal(t, []string{"SET MT_DOP=2", "SELECT 1", "INSERT INTO t SELECT 1", "SELECT 2", "SET MT_DOP=0"},
+			fake.getStatements())
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("no result sets", func(t *testing.T) {
+		fake := &fakeHS2{}
+		db := start(t, fake, true)
+		rows, err := db.QueryContext(ctx, "SET MT_DOP=2; INSERT INTO t SELECT 1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"SET MT_DOP=2", "INSERT INTO t SELECT 1"}, fake.getStatements())
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("failed statement", func(t *testing.T) {
+		fake := &fakeHS2{failStatement: "SELEC "}
+		db := start(t, fake, true)
+		_, err := db.ExecContext(ctx, "SET MT_DOP=2; SELEC 1; SELECT 2")
+		require.ErrorContains(t, err, "Syntax error (statement 1: SELEC 1)")
+		index, stmt, ok := StatementFromError(err)
+		require.True(t, ok)
+		require.Equal(t, 1, index)
+		require.Equal(t, "SELEC 1", stmt)
+		require.Equal(t, []string{"SET MT_DOP=2", "SELEC 1"}, fake.getStatements())
+
+		rows, err := db.QueryContext(ctx, "SELECT 1; SELEC 2")
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+		require.False(t, rows.NextResultSet())
+		index, _, ok = StatementFromError(rows.Err())
+		require.True(t, ok)
+		require.Equal(t, 1, index)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		fake := &fakeHS2{}
+		db := start(t, fake, false)
+		_, err := db.ExecContext(ctx, "SET MT_DOP=2; SELECT 1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"SET MT_DOP=2; SELECT 1"}, fake.getStatements())
+		_, _, ok := StatementFromError(err)
+		require.False(t, ok)
+	})
+}